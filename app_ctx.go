@@ -1,10 +1,13 @@
 package plugGo
 
 import (
+	"context"
 	"os"
 	"os/signal"
+	"sort"
 	"sync"
 	"syscall"
+	"time"
 )
 
 // AppContext is the global application context.
@@ -15,24 +18,20 @@ type AppContext struct {
 	entries map[string]map[string]Entry
 
 	// regFuncs stores all Entry registration functions.
-	// Structure: map[entryType][]RegFunc
-	regFuncs map[string][]RegFunc
+	// Structure: map[entryType][]RegFuncWithError, RegFuncs never report errors
+	regFuncs map[string][]RegFuncWithError
 
-	// shutdownHooks stores shutdown hooks.
-	shutdownHooks map[string]ShutdownHook
-
-	// shutdownSig is the shutdown signal channel.
-	shutdownSig chan os.Signal
+	// shutdownHooks stores shutdown hooks in run order (priority, then registration).
+	shutdownHooks []*shutdownHook
+	shutdownSeq   uint64
 
 	mu sync.RWMutex
 }
 
 // GlobalAppCtx is the global application context singleton.
 var GlobalAppCtx = &AppContext{
-	entries:       make(map[string]map[string]Entry),
-	regFuncs:      make(map[string][]RegFunc),
-	shutdownHooks: make(map[string]ShutdownHook),
-	shutdownSig:   make(chan os.Signal, 1),
+	entries:  make(map[string]map[string]Entry),
+	regFuncs: make(map[string][]RegFuncWithError),
 }
 
 // RegisterEntry registers an Entry to the global context.
@@ -100,6 +99,12 @@ func (ctx *AppContext) RemoveEntry(entryType, entryName string) {
 
 // RegisterPluginEntryRegFunc registers a plugin Entry registration function.
 func (ctx *AppContext) RegisterPluginEntryRegFunc(f RegFunc) {
+	ctx.RegisterPluginEntryRegFuncWithError(withoutError(f))
+}
+
+// RegisterPluginEntryRegFuncWithError registers a plugin Entry registration function
+// that reports the Entries it could not create.
+func (ctx *AppContext) RegisterPluginEntryRegFuncWithError(f RegFuncWithError) {
 	ctx.mu.Lock()
 	defer ctx.mu.Unlock()
 	ctx.regFuncs[PluginEntryType] = append(ctx.regFuncs[PluginEntryType], f)
@@ -107,48 +112,214 @@ func (ctx *AppContext) RegisterPluginEntryRegFunc(f RegFunc) {
 
 // RegisterUserEntryRegFunc registers a user-defined Entry registration function.
 func (ctx *AppContext) RegisterUserEntryRegFunc(f RegFunc) {
+	ctx.RegisterUserEntryRegFuncWithError(withoutError(f))
+}
+
+// RegisterUserEntryRegFuncWithError registers a user-defined Entry registration function
+// that reports the Entries it could not create.
+func (ctx *AppContext) RegisterUserEntryRegFuncWithError(f RegFuncWithError) {
 	ctx.mu.Lock()
 	defer ctx.mu.Unlock()
 	ctx.regFuncs[UserEntryType] = append(ctx.regFuncs[UserEntryType], f)
 }
 
 // ListPluginEntryRegFunc lists all plugin Entry registration functions.
+// Use ListPluginEntryRegFuncWithError to also get their errors.
 func (ctx *AppContext) ListPluginEntryRegFunc() []RegFunc {
+	return dropErrors(ctx.ListPluginEntryRegFuncWithError())
+}
+
+// ListPluginEntryRegFuncWithError lists all plugin Entry registration functions.
+func (ctx *AppContext) ListPluginEntryRegFuncWithError() []RegFuncWithError {
 	ctx.mu.RLock()
 	defer ctx.mu.RUnlock()
-	return ctx.regFuncs[PluginEntryType]
+	return append([]RegFuncWithError(nil), ctx.regFuncs[PluginEntryType]...)
 }
 
 // ListUserEntryRegFunc lists all user-defined Entry registration functions.
+// Use ListUserEntryRegFuncWithError to also get their errors.
 func (ctx *AppContext) ListUserEntryRegFunc() []RegFunc {
+	return dropErrors(ctx.ListUserEntryRegFuncWithError())
+}
+
+// ListUserEntryRegFuncWithError lists all user-defined Entry registration functions.
+func (ctx *AppContext) ListUserEntryRegFuncWithError() []RegFuncWithError {
 	ctx.mu.RLock()
 	defer ctx.mu.RUnlock()
-	return ctx.regFuncs[UserEntryType]
+	return append([]RegFuncWithError(nil), ctx.regFuncs[UserEntryType]...)
+}
+
+// withoutError adapts a RegFunc to a RegFuncWithError that never fails.
+func withoutError(f RegFunc) RegFuncWithError {
+	return func(raw []byte) (map[string]Entry, error) {
+		return f(raw), nil
+	}
+}
+
+// dropErrors adapts registration functions to RegFuncs ignoring their errors.
+func dropErrors(funcs []RegFuncWithError) []RegFunc {
+	result := make([]RegFunc, len(funcs))
+	for i, f := range funcs {
+		result[i] = func(raw []byte) map[string]Entry {
+			entries, _ := f(raw)
+			return entries
+		}
+	}
+	return result
+}
+
+// shutdownHook is a named shutdown hook with its ordering.
+type shutdownHook struct {
+	name         string
+	priority     int
+	seq          uint64        // Registration order
+	timeout      time.Duration // 0 uses the Boot's ShutdownHookTimeout
+	afterEntries bool          // Run once Entries are interrupted instead of before
+	fn           ShutdownHookFunc
+}
+
+// ShutdownHookOption is a shutdown hook configuration option function.
+type ShutdownHookOption func(*shutdownHook)
+
+// WithShutdownHookTimeout bounds how long the hook may run before it is abandoned,
+// overriding the Boot's ShutdownHookTimeout.
+func WithShutdownHookTimeout(timeout time.Duration) ShutdownHookOption {
+	return func(h *shutdownHook) {
+		h.timeout = timeout
+	}
+}
+
+// WithShutdownHookAfterEntries runs the hook after all Entries are interrupted,
+// e.g. to close a connection pool they use. By default hooks run before.
+func WithShutdownHookAfterEntries() ShutdownHookOption {
+	return func(h *shutdownHook) {
+		h.afterEntries = true
+	}
 }
 
-// AddShutdownHook adds a shutdown hook.
+// AddShutdownHook adds a shutdown hook with priority 0.
+// A hook with the same name is replaced.
 func (ctx *AppContext) AddShutdownHook(name string, hook ShutdownHook) {
+	if hook == nil {
+		return
+	}
+	ctx.AddShutdownHookWithContext(name, 0, func(context.Context) error {
+		hook()
+		return nil
+	})
+}
+
+// AddShutdownHookWithContext adds a shutdown hook receiving the shutdown context.
+// Hooks run in ascending priority, then registration order. A hook with the
+// same name is replaced and keeps its registration position.
+//
+// Parameters:
+//   - name: hook name, unique among shutdown hooks
+//   - priority: lower priorities run first
+//   - hook: the hook; a returned error is recorded in the shutdown report
+//   - opts: WithShutdownHookTimeout, WithShutdownHookAfterEntries
+func (ctx *AppContext) AddShutdownHookWithContext(name string, priority int, hook ShutdownHookFunc, opts ...ShutdownHookOption) {
+	if hook == nil {
+		return
+	}
+	h := &shutdownHook{name: name, priority: priority, fn: hook}
+	for _, opt := range opts {
+		opt(h)
+	}
+
 	ctx.mu.Lock()
 	defer ctx.mu.Unlock()
-	ctx.shutdownHooks[name] = hook
+
+	replaced := false
+	for i, existing := range ctx.shutdownHooks {
+		if existing.name == name {
+			h.seq = existing.seq
+			ctx.shutdownHooks[i] = h
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		ctx.shutdownSeq++
+		h.seq = ctx.shutdownSeq
+		ctx.shutdownHooks = append(ctx.shutdownHooks, h)
+	}
+	sort.SliceStable(ctx.shutdownHooks, func(i, j int) bool {
+		a, b := ctx.shutdownHooks[i], ctx.shutdownHooks[j]
+		if a.priority != b.priority {
+			return a.priority < b.priority
+		}
+		return a.seq < b.seq
+	})
+}
+
+// RemoveShutdownHook removes a shutdown hook.
+func (ctx *AppContext) RemoveShutdownHook(name string) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	for i, h := range ctx.shutdownHooks {
+		if h.name == name {
+			ctx.shutdownHooks = append(ctx.shutdownHooks[:i], ctx.shutdownHooks[i+1:]...)
+			return
+		}
+	}
 }
 
 // ListShutdownHooks lists all shutdown hooks.
+// Prefer ShutdownHookNames for the run order; hooks listed here run with a background context.
 func (ctx *AppContext) ListShutdownHooks() map[string]ShutdownHook {
-	ctx.mu.RLock()
-	defer ctx.mu.RUnlock()
-
 	result := make(map[string]ShutdownHook)
-	for k, v := range ctx.shutdownHooks {
-		result[k] = v
+	for _, h := range ctx.orderedShutdownHooks() {
+		fn := h.fn
+		result[h.name] = func() { _ = fn(context.Background()) }
 	}
 	return result
 }
 
-// WaitForShutdownSig waits for shutdown signal.
+// ShutdownHookNames returns the names of the shutdown hooks in run order.
+func (ctx *AppContext) ShutdownHookNames() []string {
+	hooks := ctx.orderedShutdownHooks()
+	names := make([]string, len(hooks))
+	for i, h := range hooks {
+		names[i] = h.name
+	}
+	return names
+}
+
+// orderedShutdownHooks returns a snapshot of the shutdown hooks in run order.
+func (ctx *AppContext) orderedShutdownHooks() []*shutdownHook {
+	ctx.mu.RLock()
+	defer ctx.mu.RUnlock()
+	return append([]*shutdownHook(nil), ctx.shutdownHooks...)
+}
+
+// WaitForShutdownSig waits for SIGINT or SIGTERM.
 func (ctx *AppContext) WaitForShutdownSig() {
-	signal.Notify(ctx.shutdownSig, syscall.SIGINT, syscall.SIGTERM)
-	<-ctx.shutdownSig
+	ctx.WaitForSignal(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+}
+
+// WaitForSignal waits for one of the signals, or until waitCtx is done.
+// It can be called any number of times; the signals are only caught while waiting.
+//
+// Returns:
+//   - os.Signal: the signal received, nil if waitCtx was done first
+func (ctx *AppContext) WaitForSignal(waitCtx context.Context, signals ...os.Signal) os.Signal {
+	if len(signals) == 0 {
+		<-waitCtx.Done()
+		return nil
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, signals...)
+	defer signal.Stop(sigCh)
+
+	select {
+	case sig := <-sigCh:
+		return sig
+	case <-waitCtx.Done():
+		return nil
+	}
 }
 
 // ===== Global convenience functions =====
@@ -158,11 +329,23 @@ func RegisterPluginEntryRegFunc(f RegFunc) {
 	GlobalAppCtx.RegisterPluginEntryRegFunc(f)
 }
 
+// RegisterPluginEntryRegFuncWithError registers a plugin Entry registration function
+// reporting errors (global convenience function).
+func RegisterPluginEntryRegFuncWithError(f RegFuncWithError) {
+	GlobalAppCtx.RegisterPluginEntryRegFuncWithError(f)
+}
+
 // RegisterUserEntryRegFunc registers a user-defined Entry registration function (global convenience function).
 func RegisterUserEntryRegFunc(f RegFunc) {
 	GlobalAppCtx.RegisterUserEntryRegFunc(f)
 }
 
+// RegisterUserEntryRegFuncWithError registers a user-defined Entry registration function
+// reporting errors (global convenience function).
+func RegisterUserEntryRegFuncWithError(f RegFuncWithError) {
+	GlobalAppCtx.RegisterUserEntryRegFuncWithError(f)
+}
+
 // RegisterEntry registers an Entry (global convenience function).
 func RegisterEntry(entry Entry) {
 	GlobalAppCtx.RegisterEntry(entry)
@@ -0,0 +1,47 @@
+package plugGo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// AuditSink receives lifecycle history events for durable auditing.
+type AuditSink interface {
+	// Record stores a single event. Implementations must be safe for concurrent use.
+	Record(event HistoryEvent) error
+}
+
+// JSONLinesAuditSink is an append-only audit sink writing one JSON object per line.
+type JSONLinesAuditSink struct {
+	file *os.File
+	enc  *json.Encoder
+	mu   sync.Mutex
+}
+
+// NewJSONLinesAuditSink opens (or creates) an audit file in append mode.
+func NewJSONLinesAuditSink(path string) (*JSONLinesAuditSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit file %s: %w", path, err)
+	}
+	return &JSONLinesAuditSink{
+		file: file,
+		enc:  json.NewEncoder(file),
+	}, nil
+}
+
+// Record appends an event as a JSON line.
+func (s *JSONLinesAuditSink) Record(event HistoryEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(event)
+}
+
+// Close closes the underlying file.
+func (s *JSONLinesAuditSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
@@ -3,54 +3,89 @@ package plugGo
 import (
 	"context"
 	"embed"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime/debug"
+	"runtime/pprof"
+	"sort"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
-// hookFuncM is the hook function map type.
-type hookFuncM map[string]map[string]func(ctx context.Context)
-
-func newHookFuncM() hookFuncM {
-	return make(hookFuncM)
-}
-
-func (m hookFuncM) addFunc(entryType, entryName string, f func(ctx context.Context)) {
-	if _, ok := m[entryType]; !ok {
-		m[entryType] = make(map[string]func(ctx context.Context))
-	}
-	m[entryType][entryName] = f
-}
-
-func (m hookFuncM) getFunc(entryType, entryName string) func(ctx context.Context) {
-	if inner, ok := m[entryType]; ok {
-		if f, ok := inner[entryName]; ok {
-			return f
-		}
-	}
-	return func(ctx context.Context) {}
-}
-
 // Boot is the bootstrapper struct.
 type Boot struct {
 	configPath    string
 	configRaw     []byte
 	embedFS       *embed.FS
-	beforeHookF   hookFuncM
-	afterHookF    hookFuncM
+	hooks         hookRegistry
 	pluginEntries map[string]map[string]Entry
 	userEntries   map[string]map[string]Entry
 	logger        Logger
 	mu            sync.RWMutex
 
+	// lifecycleMu serializes Bootstrap, Shutdown and Entry management (AddEntry, RemoveEntry...)
+	lifecycleMu  sync.Mutex
+	bootstrapped bool // Bootstrap has run, whatever its outcome (guarded by lifecycleMu)
+	shutDown     bool // Shutdown has run (guarded by lifecycleMu)
+
 	// Shutdown timeout configuration
 	shutdownTimeout      time.Duration
 	entryShutdownTimeout time.Duration
+	shutdownHookTimeout  time.Duration
+	drainPeriod          time.Duration
+	drainTimeout         time.Duration
+	ready                atomic.Bool // True from a successful Bootstrap until Shutdown starts
+
+	// Startup configuration
+	startupTimeout        time.Duration
+	entryBootstrapTimeout time.Duration
+	startupSignals        []os.Signal
+	bootstrapWorkers      int
+
+	// Signal handling after startup
+	shutdownSignals []os.Signal
+	signalActions   map[os.Signal]SignalAction
+	debugMu         sync.Mutex
+	debugLevels     []loggerLevel // Levels to restore when debug is toggled off, nil when off
+
+	buildResults     []EntryResult      // Entries the registration functions failed to create, set by NewBoot
+	startedEntries   map[entryKey]bool  // Entries to interrupt: bootstrapped (or abandoned while bootstrapping) and not interrupted since (guarded by mu)
+	bootstrapResults []EntryResult      // Results of the last Bootstrap (guarded by mu)
+	startupSignal    os.Signal          // Signal that cancelled the last Bootstrap (guarded by mu)
+	statusSubs       []hookSubscription // Subscriptions feeding HookStatusChange hooks (guarded by mu)
+	shutdownReport   *ShutdownReport    // Report of the last Shutdown (guarded by mu)
+}
+
+// Default startup and shutdown limits.
+const (
+	defaultShutdownTimeout       = 30 * time.Second
+	defaultEntryShutdownTimeout  = 10 * time.Second
+	defaultShutdownHookTimeout   = 10 * time.Second
+	defaultDrainTimeout          = 10 * time.Second
+	defaultEntryBootstrapTimeout = 60 * time.Second
+)
+
+// entryKey identifies an Entry of a Boot.
+type entryKey struct {
+	entryType string
+	entryName string
+}
+
+// bootItem is an Entry with its type and name, in bootstrap order.
+type bootItem struct {
+	entryType string
+	entryName string
+	entry     Entry
+	stage     int // 0 for plugin Entries, 1 for user Entries
+	order     int // BootstrapOrder within the stage
 }
 
 // NewBoot creates a new Boot instance.
@@ -63,45 +98,88 @@ func NewBoot(opts ...BootOption) *Boot {
 	}
 
 	boot := &Boot{
-		configPath:           cfg.ConfigPath,
-		configRaw:            cfg.ConfigRaw,
-		beforeHookF:          newHookFuncM(),
-		afterHookF:           newHookFuncM(),
-		pluginEntries:        make(map[string]map[string]Entry),
-		userEntries:          make(map[string]map[string]Entry),
-		logger:               NewDefaultLogger("boot"),
-		shutdownTimeout:      cfg.ShutdownTimeout,
-		entryShutdownTimeout: cfg.EntryShutdownTimeout,
+		configPath:            cfg.ConfigPath,
+		configRaw:             cfg.ConfigRaw,
+		pluginEntries:         make(map[string]map[string]Entry),
+		userEntries:           make(map[string]map[string]Entry),
+		startedEntries:        make(map[entryKey]bool),
+		logger:                NewDefaultLogger("boot"),
+		shutdownTimeout:       cfg.ShutdownTimeout,
+		entryShutdownTimeout:  cfg.EntryShutdownTimeout,
+		shutdownHookTimeout:   cfg.ShutdownHookTimeout,
+		drainPeriod:           cfg.DrainPeriod,
+		drainTimeout:          cfg.DrainTimeout,
+		startupTimeout:        cfg.StartupTimeout,
+		entryBootstrapTimeout: cfg.EntryBootstrapTimeout,
+		startupSignals:        cfg.StartupSignals,
+		bootstrapWorkers:      cfg.BootstrapWorkers,
+	}
+	if boot.startupSignals == nil {
+		boot.startupSignals = []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+	}
+	boot.shutdownSignals = cfg.ShutdownSignals
+	if boot.shutdownSignals == nil {
+		boot.shutdownSignals = []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+	}
+	boot.signalActions = defaultSignalActions()
+	for sig, action := range cfg.SignalActions {
+		if action == nil {
+			delete(boot.signalActions, sig)
+		} else {
+			boot.signalActions[sig] = action
+		}
 	}
 
 	// Read config
 	raw := boot.readYAML()
 
-	// Call all plugin registration functions to create Entries
-	for _, f := range GlobalAppCtx.ListPluginEntryRegFunc() {
-		for name, entry := range f(raw) {
-			entryType := entry.GetType()
-			if boot.pluginEntries[entryType] == nil {
-				boot.pluginEntries[entryType] = make(map[string]Entry)
-			}
-			boot.pluginEntries[entryType][name] = entry
-			GlobalAppCtx.RegisterEntry(entry)
-		}
+	// Call all plugin registration functions, then user ones, to create Entries
+	for _, f := range GlobalAppCtx.ListPluginEntryRegFuncWithError() {
+		boot.addRegistered(boot.pluginEntries, PluginEntryType, f, raw)
+	}
+	for _, f := range GlobalAppCtx.ListUserEntryRegFuncWithError() {
+		boot.addRegistered(boot.userEntries, UserEntryType, f, raw)
 	}
 
-	// Call all user registration functions to create Entries
-	for _, f := range GlobalAppCtx.ListUserEntryRegFunc() {
-		for name, entry := range f(raw) {
-			entryType := entry.GetType()
-			if boot.userEntries[entryType] == nil {
-				boot.userEntries[entryType] = make(map[string]Entry)
-			}
-			boot.userEntries[entryType][name] = entry
-			GlobalAppCtx.RegisterEntry(entry)
+	return boot
+}
+
+// addRegistered adds the Entries a registration function creates and records the
+// ones it could not create, reported as failed by Bootstrap.
+func (b *Boot) addRegistered(entries map[string]map[string]Entry, kind string, f RegFuncWithError, raw []byte) {
+	created, err := f(raw)
+	for name, entry := range created {
+		entryType := entry.GetType()
+		if entries[entryType] == nil {
+			entries[entryType] = make(map[string]Entry)
 		}
+		entries[entryType][name] = entry
+		GlobalAppCtx.RegisterEntry(entry)
 	}
+	for _, e := range splitErrors(err) {
+		r := EntryResult{Type: kind, Outcome: OutcomeError, Error: e.Error()}
+		var buildErr *EntryBuildError
+		if errors.As(e, &buildErr) {
+			r.Type, r.Name, r.Error = buildErr.Type, buildErr.Name, "not created: "+buildErr.Err.Error()
+		}
+		b.logger.Error("Failed to create Entry " + r.String())
+		b.buildResults = append(b.buildResults, r)
+	}
+}
 
-	return boot
+// splitErrors returns the errors joined with errors.Join in err, or err itself.
+func splitErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var errs []error
+		for _, e := range joined.Unwrap() {
+			errs = append(errs, splitErrors(e)...)
+		}
+		return errs
+	}
+	return []error{err}
 }
 
 // WithEmbedFS sets the embedded file system.
@@ -112,71 +190,549 @@ func WithEmbedFS(fs *embed.FS) BootOption {
 }
 
 // AddHookFuncBeforeBootstrap adds a hook function to run before Bootstrap.
+// Unlike AddHook, entryType and entryName are matched literally ("" only matches an
+// empty name, no wildcards nor patterns) and a later call for the same Entry
+// replaces the hook. The hook never vetoes.
 func (b *Boot) AddHookFuncBeforeBootstrap(entryType, entryName string, f func(ctx context.Context)) {
-	if f == nil {
-		return
-	}
-	b.beforeHookF.addFunc(entryType, entryName, f)
+	b.addLegacyHook(HookBeforeBootstrap, entryType, entryName, f)
 }
 
 // AddHookFuncAfterBootstrap adds a hook function to run after Bootstrap.
+// entryType and entryName are matched as in AddHookFuncBeforeBootstrap.
 func (b *Boot) AddHookFuncAfterBootstrap(entryType, entryName string, f func(ctx context.Context)) {
+	b.addLegacyHook(HookAfterBootstrap, entryType, entryName, f)
+}
+
+// addLegacyHook registers an exact hook, replacing the previous one of the same Entry.
+func (b *Boot) addLegacyHook(phase HookPhase, entryType, entryName string, f func(ctx context.Context)) {
 	if f == nil {
 		return
 	}
-	b.afterHookF.addFunc(entryType, entryName, f)
+	b.hooks.add(&hook{phase: phase, entryType: entryType, entryName: entryName, exact: true,
+		fn: func(ctx context.Context, _ HookEvent) error {
+			f(ctx)
+			return nil
+		}})
 }
 
-// Bootstrap starts all Entries.
-func (b *Boot) Bootstrap(ctx context.Context) {
+// Bootstrap starts all Entries: plugin Entries first, then user Entries, each in
+// BootstrapOrder then type and name order. With WithParallelBootstrap, Entries of
+// the same stage and order start concurrently on a bounded worker pool.
+// Each Entry gets the EntryBootstrapTimeout (or its own, see BootstrapTimeoutEntry);
+// an Entry that doesn't return in time is abandoned and reported as stuck.
+// Startup stops early when ctx is done, the StartupTimeout expires or a startup
+// signal arrives; the remaining Entries are reported as cancelled. Entries that
+// NewBoot failed to create, e.g. with an invalid config, are reported as failed.
+//
+// Returns:
+//   - error: nil if every Entry bootstrapped, otherwise a *BootstrapError
+func (b *Boot) Bootstrap(ctx context.Context) error {
 	defer b.syncLog()
 
-	// Start plugin Entries
-	for entryType, byName := range b.pluginEntries {
-		for entryName, entry := range byName {
-			b.beforeHookF.getFunc(entryType, entryName)(ctx)
-			b.logger.Info(fmt.Sprintf("Bootstrapping [%s] %s", entryType, entryName))
-			entry.Bootstrap(ctx)
-			b.afterHookF.getFunc(entryType, entryName)(ctx)
+	b.lifecycleMu.Lock()
+	defer b.lifecycleMu.Unlock()
+	b.bootstrapped = true
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if b.startupTimeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, b.startupTimeout)
+		defer cancelTimeout()
+	}
+	caught := b.cancelOnSignals(ctx, cancel)
+
+	begin := time.Now()
+	items := b.bootItems()
+	results := make([]EntryResult, len(items))
+	for start := 0; start < len(items); {
+		end := start + 1
+		for end < len(items) && items[end].stage == items[start].stage && items[end].order == items[start].order {
+			end++
 		}
+		b.bootstrapGroup(ctx, items[start:end], results[start:end])
+		start = end
 	}
 
-	// Start user Entries
-	for entryType, byName := range b.userEntries {
-		for entryName, entry := range byName {
-			b.beforeHookF.getFunc(entryType, entryName)(ctx)
-			b.logger.Info(fmt.Sprintf("Bootstrapping [%s] %s", entryType, entryName))
-			entry.Bootstrap(ctx)
-			b.afterHookF.getFunc(entryType, entryName)(ctx)
+	results = append(append([]EntryResult(nil), b.buildResults...), results...)
+	startupErr := ctx.Err()
+	cancel()
+	sig := <-caught
+	b.mu.Lock()
+	b.bootstrapResults = results
+	b.startupSignal = sig
+	b.mu.Unlock()
+	b.logBootstrapResults(results, time.Since(begin))
+
+	bootErr := &BootstrapError{Cause: startupErr, Signal: sig}
+	if sig != nil && startupErr == nil {
+		bootErr.Cause = context.Canceled
+	}
+	for _, r := range results {
+		if r.Outcome != OutcomeOK {
+			bootErr.Failed = append(bootErr.Failed, r)
 		}
 	}
+	if bootErr.Cause == nil && len(bootErr.Failed) == 0 {
+		b.ready.Store(true)
+		return nil
+	}
+	b.logger.Error(bootErr.Error())
+	return bootErr
 }
 
-// WaitForShutdownSig waits for shutdown signal.
-func (b *Boot) WaitForShutdownSig(ctx context.Context) {
-	GlobalAppCtx.WaitForShutdownSig()
-	b.Shutdown(ctx)
+// bootstrapGroup bootstraps Entries without ordering constraints between them,
+// up to bootstrapWorkers at a time, storing each result at the Entry's index.
+func (b *Boot) bootstrapGroup(ctx context.Context, items []bootItem, results []EntryResult) {
+	workers := b.bootstrapWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				if ctx.Err() != nil {
+					results[i] = EntryResult{
+						Type: items[i].entryType, Name: items[i].entryName,
+						Outcome: OutcomeCancelled, Error: "not started: startup cancelled",
+					}
+					continue
+				}
+				results[i] = b.bootstrapEntry(ctx, items[i])
+			}
+		}()
+	}
+	for i := range items {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
+
+// logBootstrapResults logs the outcome and duration of each Entry.
+func (b *Boot) logBootstrapResults(results []EntryResult, elapsed time.Duration) {
+	counts := make(map[Outcome]int)
+	for _, r := range results {
+		counts[r.Outcome]++
+		b.logger.Info("Bootstrap result " + r.String())
+	}
+	b.logger.Info(fmt.Sprintf("Bootstrap finished in %v: %d ok, %d error, %d timeout, %d cancelled",
+		elapsed.Round(time.Millisecond), counts[OutcomeOK], counts[OutcomeError], counts[OutcomeTimeout], counts[OutcomeCancelled]))
+}
+
+// BootstrapResults returns the per-Entry results of the last Bootstrap, in bootstrap order.
+func (b *Boot) BootstrapResults() []EntryResult {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]EntryResult(nil), b.bootstrapResults...)
+}
+
+// cancelOnSignals cancels startup when a startup signal arrives, until ctx is done.
+// The returned channel yields the caught signal (nil if none) once ctx is done.
+func (b *Boot) cancelOnSignals(ctx context.Context, cancel context.CancelFunc) <-chan os.Signal {
+	caught := make(chan os.Signal, 1)
+	if len(b.startupSignals) == 0 {
+		caught <- nil
+		return caught
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, b.startupSignals...)
+	go func() {
+		defer signal.Stop(sigCh)
+		select {
+		case sig := <-sigCh:
+			b.logger.Warn(fmt.Sprintf("Received %v during bootstrap, cancelling startup", sig))
+			cancel()
+			caught <- sig
+		case <-ctx.Done():
+			caught <- nil
+		}
+	}()
+	return caught
+}
+
+// bootItems returns the Entries in bootstrap order: plugin Entries, then user Entries,
+// each sorted by BootstrapOrder, type and name.
+func (b *Boot) bootItems() []bootItem {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var items []bootItem
+	for stage, group := range []map[string]map[string]Entry{b.pluginEntries, b.userEntries} {
+		for entryType, byName := range group {
+			for entryName, entry := range byName {
+				item := bootItem{entryType: entryType, entryName: entryName, entry: entry, stage: stage}
+				if e, ok := entry.(BootstrapOrderEntry); ok {
+					item.order = e.BootstrapOrder()
+				}
+				items = append(items, item)
+			}
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		a, c := items[i], items[j]
+		switch {
+		case a.stage != c.stage:
+			return a.stage < c.stage
+		case a.order != c.order:
+			return a.order < c.order
+		case a.entryType != c.entryType:
+			return a.entryType < c.entryType
+		default:
+			return a.entryName < c.entryName
+		}
+	})
+	return items
+}
+
+// bootstrapEntry runs the hooks and Bootstrap of one Entry with its timeout.
+// An Entry that bootstrapped, or was abandoned while bootstrapping and may still
+// complete, is marked as started so it gets interrupted.
+func (b *Boot) bootstrapEntry(ctx context.Context, item bootItem) (result EntryResult) {
+	result = EntryResult{Type: item.entryType, Name: item.entryName}
+	begin := time.Now()
+	defer func() {
+		b.setStarted(item.entryType, item.entryName, result.Outcome == OutcomeOK || result.Outcome == OutcomeTimeout)
+	}()
+
+	timeout := b.entryBootstrapTimeout
+	if timeout <= 0 {
+		timeout = defaultEntryBootstrapTimeout
+	}
+	if e, ok := item.entry.(BootstrapTimeoutEntry); ok && e.BootstrapTimeout() > 0 {
+		timeout = e.BootstrapTimeout()
+	}
+	entryCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Hooks run within the Entry's timeout, so a hook stuck past it counts as a timeout too
+	var stage atomic.Value // What the Entry is running: "BeforeBootstrap hooks", "Bootstrap"...
+	stage.Store(HookBeforeBootstrap.String() + " hooks")
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		event := HookEvent{Phase: HookBeforeBootstrap, EntryType: item.entryType, EntryName: item.entryName, Entry: item.entry}
+		if err := b.runHooks(entryCtx, event); err != nil {
+			done <- err
+			return
+		}
+		if err := entryCtx.Err(); err != nil {
+			done <- err // Abandoned while in the hooks, don't start it anymore
+			return
+		}
+		b.attachHooks(item.entryType, item.entryName, item.entry)
+		b.logger.Info(fmt.Sprintf("Bootstrapping [%s] %s", item.entryType, item.entryName))
+
+		stage.Store("Bootstrap")
+		var err error
+		if e, ok := item.entry.(BootstrapErrorEntry); ok {
+			err = e.BootstrapWithError(entryCtx)
+		} else {
+			item.entry.Bootstrap(entryCtx)
+		}
+		if err != nil {
+			done <- err
+			return
+		}
+
+		stage.Store(HookAfterBootstrap.String() + " hooks")
+		event.Phase = HookAfterBootstrap
+		_ = b.runHooks(entryCtx, event)
+		done <- nil
+	}()
+
+	select {
+	case err := <-done:
+		result.Duration = time.Since(begin)
+		if err != nil {
+			result.Outcome, result.Error = OutcomeError, err.Error()
+			var veto *HookVetoError
+			if errors.As(err, &veto) {
+				b.logger.Warn(err.Error())
+			} else {
+				b.logger.Error(fmt.Sprintf("Bootstrap failed [%s] %s: %v", item.entryType, item.entryName, err))
+			}
+			return result
+		}
+		result.Outcome = OutcomeOK
+	case <-entryCtx.Done():
+		result.Duration = time.Since(begin)
+		if ctx.Err() != nil {
+			result.Outcome, result.Error = OutcomeCancelled, fmt.Sprintf("startup cancelled while in %s", stage.Load())
+		} else {
+			result.Outcome, result.Error = OutcomeTimeout, fmt.Sprintf("stuck in %s for %v, abandoned", stage.Load(), timeout)
+		}
+		b.logger.Warn(fmt.Sprintf("Bootstrap %s [%s] %s: %s", result.Outcome, item.entryType, item.entryName, result.Error))
+	}
+	return result
+}
+
+// setStarted records whether an Entry needs to be interrupted.
+func (b *Boot) setStarted(entryType, entryName string, started bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if started {
+		b.startedEntries[entryKey{entryType, entryName}] = true
+	} else {
+		delete(b.startedEntries, entryKey{entryType, entryName})
+	}
+}
+
+// isStarted reports whether an Entry bootstrapped and wasn't interrupted since.
+func (b *Boot) isStarted(entryType, entryName string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.startedEntries[entryKey{entryType, entryName}]
+}
+
+// startedItems returns the started Entries in bootstrap order.
+func (b *Boot) startedItems() []bootItem {
+	var items []bootItem
+	for _, item := range b.bootItems() {
+		if b.isStarted(item.entryType, item.entryName) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// WaitForShutdownSig waits for a shutdown signal, then shuts down and returns the report.
+// If a signal already cancelled Bootstrap, it shuts down without waiting for another one.
+// Action signals run their actions while waiting, and a second shutdown signal during
+// the shutdown forces an immediate exit (see Run).
+func (b *Boot) WaitForShutdownSig(ctx context.Context) *ShutdownReport {
+	b.mu.RLock()
+	interrupted := b.startupSignal != nil
+	b.mu.RUnlock()
+
+	signals := b.listenSignals()
+	defer signals.stop()
+	if !interrupted {
+		b.waitForSignal(context.Background(), signals)
+	}
+	return b.shutdownForcible(ctx, signals)
 }
 
-// Shutdown shuts down all Entries.
-func (b *Boot) Shutdown(ctx context.Context) {
-	// Create context with timeout (if not already set)
-	shutdownTimeout := 30 * time.Second
+// Shutdown shuts down the started Entries and reports how each hook and Entry ended.
+// Entries that failed to bootstrap, or never did, are not drained nor interrupted.
+// It first reports not ready (see Ready), waits the DrainPeriod and drains the
+// Entries implementing Drainer, so pending work completes before anything stops.
+// Shutdown hooks run in priority order before the Entries are interrupted, or after
+// them for hooks added with WithShutdownHookAfterEntries. Each hook gets the
+// ShutdownHookTimeout (or its own) and each Entry the EntryShutdownTimeout; a hook
+// or Entry that doesn't return in time is abandoned and reported as a timeout.
+// The drain has its own budget, the DrainPeriod plus the DrainTimeout; the
+// ShutdownTimeout covers the hooks and interrupts and starts once the drain ends.
+// Shutdown waits for a running Bootstrap or Entry operation (AddEntry, RestartEntry...)
+// to return before anything starts.
+//
+// Returns:
+//   - *ShutdownReport: per hook and Entry outcomes, abandoned Entries and a suggested exit code
+func (b *Boot) Shutdown(ctx context.Context) *ShutdownReport {
+	// Wait for a running Bootstrap or Entry operation first, so it doesn't eat the shutdown timeout
+	b.lifecycleMu.Lock()
+	defer b.lifecycleMu.Unlock()
+	b.shutDown = true
+
+	begin := time.Now()
+	report := &ShutdownReport{}
+	hooks := GlobalAppCtx.orderedShutdownHooks()
+
+	// 0. Lame duck: report not ready, let load balancers notice, then drain Entries.
+	// The drain is bounded by its own period and timeout, not the shutdown timeout.
+	b.ready.Store(false)
+	report.Drained = b.drain(ctx)
+
+	shutdownTimeout := defaultShutdownTimeout
 	if b.shutdownTimeout > 0 {
 		shutdownTimeout = b.shutdownTimeout
 	}
-
 	shutdownCtx, cancel := context.WithTimeout(ctx, shutdownTimeout)
 	defer cancel()
 
-	// 1. Execute shutdown hooks
-	for name, hook := range GlobalAppCtx.ListShutdownHooks() {
-		b.logger.Info(fmt.Sprintf("Running shutdown hook: %s", name))
-		hook()
-	}
+	// 1. Execute shutdown hooks that run before Entries stop
+	report.Hooks = append(report.Hooks, b.runShutdownHooks(shutdownCtx, hooks, false)...)
 
 	// 2. Interrupt all Entries concurrently with timeout control
-	b.interruptWithContext(shutdownCtx)
+	report.Entries = b.interruptWithContext(shutdownCtx)
+
+	// 3. Execute shutdown hooks that run after Entries stop
+	report.Hooks = append(report.Hooks, b.runShutdownHooks(shutdownCtx, hooks, true)...)
+
+	report.Duration = time.Since(begin)
+	report.finish()
+	if report.ExitCode == ShutdownExitOK {
+		b.logger.Info(report.String())
+	} else {
+		b.logger.Warn(report.String())
+	}
+	b.mu.Lock()
+	b.shutdownReport = report
+	b.mu.Unlock()
+	return report
+}
+
+// Ready reports whether the Boot is serving: true once Bootstrap succeeded, false
+// as soon as Shutdown starts. Serve it from a readiness probe so traffic stops
+// during the drain period.
+func (b *Boot) Ready() bool {
+	return b.ready.Load()
+}
+
+// drain waits the drain period, then drains the Entries implementing Drainer concurrently,
+// each with the drain timeout.
+func (b *Boot) drain(ctx context.Context) []EntryResult {
+	if b.drainPeriod > 0 {
+		b.logger.Info(fmt.Sprintf("Not ready, waiting %v before draining", b.drainPeriod))
+		select {
+		case <-time.After(b.drainPeriod):
+		case <-ctx.Done():
+		}
+	}
+
+	var items []bootItem
+	for _, item := range b.startedItems() {
+		if _, ok := item.entry.(Drainer); !ok {
+			continue
+		}
+		// Every PluginEntry is a Drainer; only report those whose plugin drains
+		if e, ok := item.entry.(*PluginEntry); ok {
+			if _, ok := e.instance.plugin.(Drainer); !ok {
+				continue
+			}
+		}
+		items = append(items, item)
+	}
+	timeout := defaultDrainTimeout
+	if b.drainTimeout > 0 {
+		timeout = b.drainTimeout
+	}
+
+	results := make([]EntryResult, len(items))
+	var wg sync.WaitGroup
+	for i, item := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = b.drainEntry(ctx, item, timeout)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// drainEntry drains one Entry with its timeout.
+func (b *Boot) drainEntry(ctx context.Context, item bootItem, timeout time.Duration) EntryResult {
+	result := EntryResult{Type: item.entryType, Name: item.entryName}
+	entryCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	b.logger.Info(fmt.Sprintf("Draining [%s] %s", item.entryType, item.entryName))
+	begin := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- item.entry.(Drainer).Drain(entryCtx)
+	}()
+
+	select {
+	case err := <-done:
+		result.Duration = time.Since(begin)
+		result.Outcome = OutcomeOK
+		if err != nil {
+			result.Outcome, result.Error = OutcomeError, err.Error()
+		}
+	case <-entryCtx.Done():
+		result.Duration = time.Since(begin)
+		result.Outcome, result.Error = OutcomeTimeout, fmt.Sprintf("still draining after %v, abandoned", timeout)
+	}
+	if result.Outcome != OutcomeOK {
+		b.logger.Warn(fmt.Sprintf("Drain %s [%s] %s: %s", result.Outcome, item.entryType, item.entryName, result.Error))
+	}
+	return result
+}
+
+// ShutdownReport returns the report of the last Shutdown, nil before the first one.
+func (b *Boot) ShutdownReport() *ShutdownReport {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.shutdownReport
+}
+
+// runShutdownHooks runs the shutdown hooks of one side of the Entry interrupt in order.
+func (b *Boot) runShutdownHooks(ctx context.Context, hooks []*shutdownHook, afterEntries bool) []EntryResult {
+	var results []EntryResult
+	for _, hook := range hooks {
+		if hook.afterEntries != afterEntries {
+			continue
+		}
+		results = append(results, b.runShutdownHook(ctx, hook))
+	}
+	return results
+}
+
+// runShutdownHook runs one shutdown hook with its timeout.
+func (b *Boot) runShutdownHook(ctx context.Context, hook *shutdownHook) EntryResult {
+	result := EntryResult{Type: ShutdownHookType, Name: hook.name}
+	if ctx.Err() != nil {
+		result.Outcome, result.Error = OutcomeCancelled, "not run: shutdown timeout exceeded"
+		return result
+	}
+
+	timeout := defaultShutdownHookTimeout
+	if b.shutdownHookTimeout > 0 {
+		timeout = b.shutdownHookTimeout
+	}
+	if hook.timeout > 0 {
+		timeout = hook.timeout
+	}
+	hookCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	b.logger.Info(fmt.Sprintf("Running shutdown hook: %s", hook.name))
+	begin := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- hook.fn(hookCtx)
+	}()
+
+	select {
+	case err := <-done:
+		result.Duration = time.Since(begin)
+		result.Outcome = OutcomeOK
+		if err != nil {
+			result.Outcome, result.Error = OutcomeError, err.Error()
+		}
+	case <-hookCtx.Done():
+		result.Duration = time.Since(begin)
+		result.Outcome, result.Error = OutcomeTimeout, fmt.Sprintf("still running after %v, abandoned", timeout)
+	}
+	if result.Outcome != OutcomeOK {
+		b.logger.Warn(fmt.Sprintf("Shutdown hook %s %s: %s", hook.name, result.Outcome, result.Error))
+	}
+	return result
 }
 
 // AddShutdownHookFunc adds a shutdown hook function.
@@ -184,40 +740,74 @@ func (b *Boot) AddShutdownHookFunc(name string, f ShutdownHook) {
 	GlobalAppCtx.AddShutdownHook(name, f)
 }
 
-// interruptWithContext interrupts all Entries concurrently with timeout control.
-func (b *Boot) interruptWithContext(ctx context.Context) {
+// AddShutdownHookFuncWithContext adds a shutdown hook receiving the shutdown context,
+// run in ascending priority order (see AppContext.AddShutdownHookWithContext).
+func (b *Boot) AddShutdownHookFuncWithContext(name string, priority int, f ShutdownHookFunc, opts ...ShutdownHookOption) {
+	GlobalAppCtx.AddShutdownHookWithContext(name, priority, f, opts...)
+}
+
+// interruptWithContext interrupts the started Entries concurrently with timeout control:
+// user Entries first, then plugin Entries. Entries still running when ctx is done
+// are reported as abandoned.
+func (b *Boot) interruptWithContext(ctx context.Context) []EntryResult {
 	defer b.syncLog()
 
-	var wg sync.WaitGroup
+	items := b.startedItems()
+	results := make([]EntryResult, 0, len(items))
 
-	// 1. Shutdown user Entries first (concurrent)
-	for entryType, byName := range b.userEntries {
-		for entryName, entry := range byName {
-			wg.Add(1)
-			go func(et, en string, e Entry) {
-				defer wg.Done()
-				b.interruptSingleEntry(ctx, et, en, e)
-			}(entryType, entryName, entry)
+	// 1. Shutdown user Entries first (concurrent), 2. then plugin Entries (concurrent)
+	for _, stage := range []struct {
+		stage int
+		name  string
+	}{{1, "user entries"}, {0, "plugin entries"}} {
+		var group []bootItem
+		for _, item := range items {
+			if item.stage == stage.stage {
+				group = append(group, item)
+			}
 		}
+		results = append(results, b.interruptGroup(ctx, group, stage.name)...)
 	}
 
-	// Wait for user Entries to shutdown
-	b.waitWithTimeout(ctx, &wg, "user entries")
+	b.detachHooks()
+	return results
+}
 
-	// 2. Then shutdown plugin Entries (concurrent)
-	wg = sync.WaitGroup{} // Reset
-	for entryType, byName := range b.pluginEntries {
-		for entryName, entry := range byName {
-			wg.Add(1)
-			go func(et, en string, e Entry) {
-				defer wg.Done()
-				b.interruptSingleEntry(ctx, et, en, e)
-			}(entryType, entryName, entry)
+// interruptGroup interrupts Entries concurrently and waits for them or until ctx is done.
+func (b *Boot) interruptGroup(ctx context.Context, items []bootItem, name string) []EntryResult {
+	var mu sync.Mutex
+	results := make([]EntryResult, len(items))
+	for i, item := range items {
+		results[i] = EntryResult{
+			Type: item.entryType, Name: item.entryName,
+			Outcome: OutcomeTimeout, Error: "still interrupting when the shutdown timeout was exceeded, abandoned",
 		}
 	}
 
-	// Wait for plugin Entries to shutdown
-	b.waitWithTimeout(ctx, &wg, "plugin entries")
+	var wg sync.WaitGroup
+	begin := time.Now()
+	for i, item := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := b.interruptSingleEntry(ctx, item.entryType, item.entryName, item.entry, true)
+			mu.Lock()
+			results[i] = result
+			mu.Unlock()
+		}()
+	}
+
+	// Wait for the Entries to shutdown
+	b.waitWithTimeout(ctx, &wg, name)
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i := range results {
+		if results[i].Duration == 0 && results[i].Outcome == OutcomeTimeout {
+			results[i].Duration = time.Since(begin)
+		}
+	}
+	return append([]EntryResult(nil), results...)
 }
 
 // waitWithTimeout waits for all goroutines in WaitGroup or until context timeout.
@@ -230,18 +820,33 @@ func (b *Boot) waitWithTimeout(ctx context.Context, wg *sync.WaitGroup, name str
 
 	select {
 	case <-done:
-		b.logger.Info(fmt.Sprintf("All %s interrupted successfully", name))
+		b.logger.Info(fmt.Sprintf("All %s interrupted", name))
 	case <-ctx.Done():
-		b.logger.Warn(fmt.Sprintf("Shutdown timeout exceeded for %s, forcing exit", name))
+		b.logger.Warn(fmt.Sprintf("Shutdown timeout exceeded for %s, abandoning the rest", name))
 	}
 }
 
 // interruptSingleEntry interrupts a single Entry with timeout control.
-func (b *Boot) interruptSingleEntry(ctx context.Context, entryType, entryName string, entry Entry) {
+// During shutdown a HookBeforeInterrupt veto is logged but doesn't keep the Entry running.
+func (b *Boot) interruptSingleEntry(ctx context.Context, entryType, entryName string, entry Entry, shutdown bool) EntryResult {
+	result := EntryResult{Type: entryType, Name: entryName}
+	begin := time.Now()
+
+	event := HookEvent{Phase: HookBeforeInterrupt, EntryType: entryType, EntryName: entryName, Entry: entry}
+	if err := b.runHooks(ctx, event); err != nil {
+		if shutdown {
+			b.logger.Warn(fmt.Sprintf("Interrupting anyway, shutting down: %v", err))
+		} else {
+			b.logger.Warn(fmt.Sprintf("Skipping interrupt: %v", err))
+			result.Duration = time.Since(begin)
+			result.Outcome, result.Error = OutcomeError, err.Error()
+			return result
+		}
+	}
 	b.logger.Info(fmt.Sprintf("Interrupting [%s] %s", entryType, entryName))
 
 	// Create timeout context for this Entry (default 10s)
-	entryTimeout := 10 * time.Second
+	entryTimeout := defaultEntryShutdownTimeout
 	if b.entryShutdownTimeout > 0 {
 		entryTimeout = b.entryShutdownTimeout
 	}
@@ -249,18 +854,44 @@ func (b *Boot) interruptSingleEntry(ctx context.Context, entryType, entryName st
 	entryCtx, cancel := context.WithTimeout(ctx, entryTimeout)
 	defer cancel()
 
-	done := make(chan struct{})
+	done := make(chan error, 1)
 	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		if e, ok := entry.(InterruptErrorEntry); ok {
+			done <- e.InterruptWithError(entryCtx)
+			return
+		}
 		entry.Interrupt(entryCtx)
-		close(done)
+		done <- nil
 	}()
 
+	event.Phase = HookAfterInterrupt
 	select {
-	case <-done:
-		b.logger.Info(fmt.Sprintf("Interrupted [%s] %s", entryType, entryName))
+	case err := <-done:
+		result.Duration = time.Since(begin)
+		result.Outcome = OutcomeOK
+		if err != nil {
+			result.Outcome, result.Error = OutcomeError, err.Error()
+			b.logger.Error(fmt.Sprintf("Interrupt failed [%s] %s: %v", entryType, entryName, err))
+		} else {
+			b.logger.Info(fmt.Sprintf("Interrupted [%s] %s", entryType, entryName))
+		}
+		event.Err = err
 	case <-entryCtx.Done():
+		result.Duration = time.Since(begin)
+		result.Outcome, result.Error = OutcomeTimeout, fmt.Sprintf("still interrupting after %v, abandoned", result.Duration.Round(time.Millisecond))
 		b.logger.Warn(fmt.Sprintf("Interrupt timeout [%s] %s", entryType, entryName))
+		event.Err = entryCtx.Err()
 	}
+	if result.Outcome == OutcomeOK {
+		b.setStarted(entryType, entryName, false)
+	}
+	_ = b.runHooks(ctx, event)
+	return result
 }
 
 // GetEntry returns the specified Entry.
@@ -342,21 +973,30 @@ func (b *Boot) CountEntries() int {
 	return count
 }
 
-// readYAML reads the YAML config file.
+// readYAML reads the YAML config file, exiting if it can't be read.
 func (b *Boot) readYAML() []byte {
+	res, err := b.loadYAML()
+	if err != nil {
+		b.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	return res
+}
+
+// loadYAML reads the YAML config from the raw content, the embedded file system or the config file.
+func (b *Boot) loadYAML() ([]byte, error) {
 	// Use raw config first
 	if len(b.configRaw) > 0 {
-		return b.configRaw
+		return b.configRaw, nil
 	}
 
 	// Read from embedded file system
 	if b.embedFS != nil {
 		res, err := b.embedFS.ReadFile(b.configPath)
 		if err != nil {
-			b.logger.Error(fmt.Sprintf("Failed to read config from embed FS: %v", err))
-			os.Exit(1)
+			return nil, fmt.Errorf("failed to read config from embed FS: %w", err)
 		}
-		return res
+		return res, nil
 	}
 
 	// Read from local file
@@ -367,10 +1007,101 @@ func (b *Boot) readYAML() []byte {
 
 	res, err := os.ReadFile(b.configPath)
 	if err != nil {
-		b.logger.Error(fmt.Sprintf("Failed to read config file %s: %v", b.configPath, err))
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to read config file %s: %w", b.configPath, err)
 	}
-	return res
+	return res, nil
+}
+
+// ReloadConfig re-reads the config and hands it to every Entry implementing
+// ReloadConfigEntry; plugin Entries reload their instance if their element changed.
+// Entries are neither added nor removed.
+//
+// Returns:
+//   - error: joined errors of the Entries that failed to reload (or the read error)
+func (b *Boot) ReloadConfig(ctx context.Context) error {
+	b.lifecycleMu.Lock()
+	defer b.lifecycleMu.Unlock()
+
+	raw, err := b.loadYAML()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	reloaded := 0
+	for _, item := range b.bootItems() {
+		e, ok := item.entry.(ReloadConfigEntry)
+		if !ok {
+			continue
+		}
+		if err := e.ReloadConfig(ctx, raw); err != nil {
+			errs = append(errs, fmt.Errorf("[%s] %s: %w", item.entryType, item.entryName, err))
+			continue
+		}
+		reloaded++
+	}
+	b.logger.Info(fmt.Sprintf("Config reloaded: %d entries ok, %d failed", reloaded, len(errs)))
+	return errors.Join(errs...)
+}
+
+// DumpState writes every Entry (with its status for plugin Entries) and the stacks
+// of all goroutines to w, e.g. to diagnose a stuck process.
+func (b *Boot) DumpState(w io.Writer) error {
+	fmt.Fprintf(w, "=== plugGo state at %s ===\n", time.Now().Format(time.RFC3339))
+	for _, item := range b.bootItems() {
+		if _, err := fmt.Fprintf(w, "%s\n", item.entry.String()); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(w, "=== goroutines ===\n")
+	return pprof.Lookup("goroutine").WriteTo(w, 2)
+}
+
+// levelLogger is a Logger whose level can change at runtime, such as StandardLogger.
+type levelLogger interface {
+	SetLevel(level LogLevel)
+	Level() LogLevel
+}
+
+// loggerLevel is a logger with the level it had before debug was toggled on.
+type loggerLevel struct {
+	logger levelLogger
+	level  LogLevel
+}
+
+// ToggleDebug switches the Boot logger and the plugin Entries' loggers to debug level,
+// or back to their previous levels if debug is on. Loggers without SetLevel are left alone.
+//
+// Returns:
+//   - bool: whether debug logging is now on
+func (b *Boot) ToggleDebug() bool {
+	b.debugMu.Lock()
+	defer b.debugMu.Unlock()
+
+	if b.debugLevels != nil {
+		for i := len(b.debugLevels) - 1; i >= 0; i-- {
+			b.debugLevels[i].logger.SetLevel(b.debugLevels[i].level)
+		}
+		b.debugLevels = nil
+		return false
+	}
+
+	loggers := []Logger{b.logger}
+	for _, item := range b.bootItems() {
+		if e, ok := item.entry.(*PluginEntry); ok {
+			loggers = append(loggers, e.Instance().GetLogger())
+		}
+	}
+	b.debugLevels = []loggerLevel{}
+	for _, logger := range loggers {
+		if l, ok := logger.(levelLogger); ok {
+			b.debugLevels = append(b.debugLevels, loggerLevel{logger: l, level: l.Level()})
+			if l.Level() > DebugLevel {
+				l.SetLevel(DebugLevel)
+			}
+		}
+	}
+	return true
 }
 
 // syncLog syncs logs and handles panic.
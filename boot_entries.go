@@ -0,0 +1,182 @@
+package plugGo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// The methods below are serialized with Bootstrap and Shutdown, so they must not be
+// called from hooks, which run during those.
+
+// ErrBootShutDown is returned by Entry management methods once the Boot is shut down.
+var ErrBootShutDown = errors.New("boot is shut down")
+
+// AddEntry adds an Entry at runtime: a *PluginEntry joins the plugin Entries, any
+// other Entry the user Entries. If the Boot is already bootstrapped the Entry is
+// bootstrapped right away, with hooks and its bootstrap timeout; otherwise the next
+// Bootstrap starts it. The Entry stays added even if its bootstrap fails.
+// Plugin Entries for new instances can be built with registry.BuildEntry.
+//
+// Parameters:
+//   - ctx: context for the Entry's bootstrap
+//   - entry: the Entry, whose type and name must not be in use
+//
+// Returns:
+//   - error: if the name is taken, the Boot is shut down or the bootstrap failed
+func (b *Boot) AddEntry(ctx context.Context, entry Entry) error {
+	b.lifecycleMu.Lock()
+	defer b.lifecycleMu.Unlock()
+
+	if b.shutDown {
+		return ErrBootShutDown
+	}
+	entryType, entryName := entry.GetType(), entry.GetName()
+	if b.GetEntry(entryType, entryName) != nil {
+		return fmt.Errorf("entry [%s] %s already exists", entryType, entryName)
+	}
+
+	item := bootItem{entryType: entryType, entryName: entryName, entry: entry, stage: 1}
+	if _, ok := entry.(*PluginEntry); ok {
+		item.stage = 0
+	}
+	b.mu.Lock()
+	group := b.userEntries
+	if item.stage == 0 {
+		group = b.pluginEntries
+	}
+	if group[entryType] == nil {
+		group[entryType] = make(map[string]Entry)
+	}
+	group[entryType][entryName] = entry
+	b.mu.Unlock()
+	GlobalAppCtx.RegisterEntry(entry)
+	b.logger.Info(fmt.Sprintf("Added [%s] %s", entryType, entryName))
+
+	if !b.bootstrapped {
+		return nil
+	}
+	return resultError("bootstrap", b.bootstrapEntry(ctx, item))
+}
+
+// RemoveEntry interrupts an Entry, with hooks and its shutdown timeout. Entries
+// implementing RemovableEntry are then released, e.g. plugin instances are removed
+// from the registry. The Entry is unregistered from the Boot and GlobalAppCtx last,
+// so if its interrupt fails, is vetoed or times out, or Remove fails, it stays
+// registered and RemoveEntry can be retried.
+//
+// Returns:
+//   - error: if there is no such Entry, it didn't stop cleanly or couldn't be released
+func (b *Boot) RemoveEntry(ctx context.Context, entryType, entryName string) error {
+	b.lifecycleMu.Lock()
+	defer b.lifecycleMu.Unlock()
+
+	entry := b.GetEntry(entryType, entryName)
+	if entry == nil {
+		return fmt.Errorf("entry [%s] %s not found", entryType, entryName)
+	}
+
+	if !b.shutDown && b.isStarted(entryType, entryName) {
+		if err := resultError("interrupt", b.interruptSingleEntry(ctx, entryType, entryName, entry, false)); err != nil {
+			return err
+		}
+	}
+	if e, ok := entry.(RemovableEntry); ok {
+		if err := e.Remove(); err != nil {
+			return fmt.Errorf("remove [%s] %s: %w", entryType, entryName, err)
+		}
+	}
+	b.detachEntryHooks(entry)
+
+	b.mu.Lock()
+	for _, group := range []map[string]map[string]Entry{b.pluginEntries, b.userEntries} {
+		if byName, ok := group[entryType]; ok && byName[entryName] == entry {
+			delete(byName, entryName)
+			if len(byName) == 0 {
+				delete(group, entryType)
+			}
+		}
+	}
+	delete(b.startedEntries, entryKey{entryType, entryName})
+	b.mu.Unlock()
+	GlobalAppCtx.RemoveEntry(entryType, entryName)
+	b.logger.Info(fmt.Sprintf("Removed [%s] %s", entryType, entryName))
+	return nil
+}
+
+// RestartEntry interrupts an Entry and bootstraps it again, with hooks and timeouts.
+// An Entry that failed to bootstrap is only bootstrapped.
+//
+// Returns:
+//   - error: if there is no such Entry, the Boot isn't running or a step failed
+func (b *Boot) RestartEntry(ctx context.Context, entryType, entryName string) error {
+	b.lifecycleMu.Lock()
+	defer b.lifecycleMu.Unlock()
+
+	item, err := b.runningItem(entryType, entryName)
+	if err != nil {
+		return err
+	}
+	if b.isStarted(entryType, entryName) {
+		if err := resultError("interrupt", b.interruptSingleEntry(ctx, entryType, entryName, item.entry, false)); err != nil {
+			return err
+		}
+	}
+	b.detachEntryHooks(item.entry) // bootstrapEntry attaches them again
+	return resultError("bootstrap", b.bootstrapEntry(ctx, item))
+}
+
+// ReloadEntry applies new config to one Entry implementing ReloadConfigEntry, such as
+// a plugin Entry, which reloads its instance through the reload hooks.
+//
+// Parameters:
+//   - entryType, entryName: the Entry
+//   - raw: boot.yaml content with the Entry's element, e.g. "announcement:\n  - name: official\n    ..."
+//
+// Returns:
+//   - error: if there is no such Entry, it can't reload or the reload failed
+func (b *Boot) ReloadEntry(ctx context.Context, entryType, entryName string, raw []byte) error {
+	b.lifecycleMu.Lock()
+	defer b.lifecycleMu.Unlock()
+
+	if b.shutDown {
+		return ErrBootShutDown
+	}
+	entry := b.GetEntry(entryType, entryName)
+	if entry == nil {
+		return fmt.Errorf("entry [%s] %s not found", entryType, entryName)
+	}
+	reloader, ok := entry.(ReloadConfigEntry)
+	if !ok {
+		return fmt.Errorf("entry [%s] %s does not support config reload", entryType, entryName)
+	}
+	if err := reloader.ReloadConfig(ctx, raw); err != nil {
+		return fmt.Errorf("reload [%s] %s: %w", entryType, entryName, err)
+	}
+	return nil
+}
+
+// runningItem returns the bootItem of an Entry of a bootstrapped, running Boot.
+// Note: caller must hold b.lifecycleMu.
+func (b *Boot) runningItem(entryType, entryName string) (bootItem, error) {
+	if b.shutDown {
+		return bootItem{}, ErrBootShutDown
+	}
+	if !b.bootstrapped {
+		return bootItem{}, errors.New("boot is not bootstrapped")
+	}
+	for _, item := range b.bootItems() {
+		if item.entryType == entryType && item.entryName == entryName {
+			return item, nil
+		}
+	}
+	return bootItem{}, fmt.Errorf("entry [%s] %s not found", entryType, entryName)
+}
+
+// resultError turns a failed bootstrap or interrupt result into an error.
+func resultError(step string, r EntryResult) error {
+	if r.Outcome == OutcomeOK {
+		return nil
+	}
+	return fmt.Errorf("%s [%s] %s: %s: %s", step, r.Type, r.Name, r.Outcome, r.Error)
+}
@@ -0,0 +1,162 @@
+package plugGo
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Outcome is how a bootstrap or shutdown step of an Entry ended.
+type Outcome string
+
+const (
+	// OutcomeOK means the step completed.
+	OutcomeOK Outcome = "ok"
+	// OutcomeError means the step failed.
+	OutcomeError Outcome = "error"
+	// OutcomeTimeout means the step didn't complete within its timeout and was abandoned.
+	OutcomeTimeout Outcome = "timeout"
+	// OutcomeCancelled means the step was cut short or never run because the operation was cancelled.
+	OutcomeCancelled Outcome = "cancelled"
+)
+
+// EntryResult is the outcome of bootstrapping or interrupting one Entry.
+type EntryResult struct {
+	Type     string        `json:"type"`
+	Name     string        `json:"name"`
+	Outcome  Outcome       `json:"outcome"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// String formats the result as "[type] name: outcome (duration): error".
+func (r EntryResult) String() string {
+	s := fmt.Sprintf("[%s] %s: %s (%v)", r.Type, r.Name, r.Outcome, r.Duration.Round(time.Millisecond))
+	if r.Error != "" {
+		s += ": " + r.Error
+	}
+	return s
+}
+
+// BootstrapError is returned by Boot.Bootstrap when not every Entry bootstrapped.
+type BootstrapError struct {
+	// Cause is the context error that ended startup early (nil if only Entries timed out).
+	Cause error
+	// Signal is the signal that cancelled startup, nil otherwise.
+	Signal os.Signal
+	// Failed lists the Entries that timed out, failed or were cancelled.
+	Failed []EntryResult
+}
+
+// Error lists the failed Entries, e.g. which one was stuck.
+func (e *BootstrapError) Error() string {
+	var b strings.Builder
+	b.WriteString("bootstrap incomplete")
+	switch {
+	case e.Signal != nil:
+		fmt.Fprintf(&b, " (interrupted by %v)", e.Signal)
+	case e.Cause != nil:
+		fmt.Fprintf(&b, " (%v)", e.Cause)
+	}
+	for _, r := range e.Failed {
+		b.WriteString("; ")
+		b.WriteString(r.String())
+	}
+	return b.String()
+}
+
+// Unwrap returns the cause, so errors.Is(err, context.DeadlineExceeded) detects a startup timeout.
+func (e *BootstrapError) Unwrap() error {
+	return e.Cause
+}
+
+// ShutdownHookType is the Type of shutdown hook results in a ShutdownReport.
+const ShutdownHookType = "ShutdownHook"
+
+// Exit codes suggested by ShutdownReport.ExitCode.
+const (
+	// ShutdownExitOK means every hook and Entry stopped cleanly.
+	ShutdownExitOK = 0
+	// ShutdownExitError means a hook or Entry failed, but nothing is left running.
+	ShutdownExitError = 1
+	// ShutdownExitAbandoned means a hook or Entry didn't stop in time and was left running.
+	ShutdownExitAbandoned = 2
+)
+
+// ShutdownReport is the outcome of Boot.Shutdown, meant to be logged as one record.
+type ShutdownReport struct {
+	// Duration is how long the whole shutdown took.
+	Duration time.Duration `json:"duration"`
+	// Drained lists the Entries implementing Drainer, drained before any Interrupt.
+	Drained []EntryResult `json:"drained,omitempty"`
+	// Hooks lists the shutdown hooks in run order.
+	Hooks []EntryResult `json:"hooks"`
+	// Entries lists the interrupted Entries: user Entries, then plugin Entries.
+	Entries []EntryResult `json:"entries"`
+	// Abandoned lists the Entries whose Interrupt was still running at their deadline.
+	Abandoned []EntryResult `json:"abandoned,omitempty"`
+	// ExitCode is the suggested process exit status (ShutdownExitOK, ShutdownExitError or ShutdownExitAbandoned).
+	ExitCode int `json:"exitCode"`
+}
+
+// finish fills Abandoned and ExitCode from the hook and Entry results.
+func (r *ShutdownReport) finish() {
+	r.Abandoned = nil
+	r.ExitCode = ShutdownExitOK
+	for _, results := range [][]EntryResult{r.Drained, r.Hooks} {
+		for _, res := range results {
+			r.ExitCode = max(r.ExitCode, exitCodeOf(res.Outcome))
+		}
+	}
+	for _, e := range r.Entries {
+		if e.Outcome == OutcomeTimeout {
+			r.Abandoned = append(r.Abandoned, e)
+		}
+		r.ExitCode = max(r.ExitCode, exitCodeOf(e.Outcome))
+	}
+}
+
+// exitCodeOf maps an outcome to its suggested exit code.
+func exitCodeOf(outcome Outcome) int {
+	switch outcome {
+	case OutcomeOK:
+		return ShutdownExitOK
+	case OutcomeTimeout:
+		return ShutdownExitAbandoned
+	default:
+		return ShutdownExitError
+	}
+}
+
+// String summarizes the report on one line, listing what didn't stop cleanly.
+func (r *ShutdownReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "shutdown finished in %v: %d entries, %d hooks, %d abandoned, exit code %d",
+		r.Duration.Round(time.Millisecond), len(r.Entries), len(r.Hooks), len(r.Abandoned), r.ExitCode)
+	for _, results := range [][]EntryResult{r.Drained, r.Hooks, r.Entries} {
+		for _, res := range results {
+			if res.Outcome != OutcomeOK {
+				b.WriteString("; ")
+				b.WriteString(res.String())
+			}
+		}
+	}
+	return b.String()
+}
+
+// Err returns an error listing the hooks and Entries that failed or timed out, nil if all succeeded.
+func (r *ShutdownReport) Err() error {
+	var failed []string
+	for _, results := range [][]EntryResult{r.Drained, r.Hooks, r.Entries} {
+		for _, res := range results {
+			if res.Outcome != OutcomeOK {
+				failed = append(failed, res.String())
+			}
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("shutdown incomplete; %s", strings.Join(failed, "; "))
+}
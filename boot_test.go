@@ -0,0 +1,581 @@
+package plugGo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stubEntry is a user Entry recording its calls, with injectable failures and delays.
+type stubEntry struct {
+	name  string
+	order int
+	log   *callLog
+
+	mu             sync.Mutex
+	bootstrapErr   error         // Returned by BootstrapWithError
+	interruptErr   error         // Returned by InterruptWithError
+	bootstrapDelay time.Duration // BootstrapWithError waits this long (or until ctx is done)
+	interruptDelay time.Duration // InterruptWithError waits this long (or until ctx is done)
+}
+
+func (e *stubEntry) wait(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *stubEntry) BootstrapWithError(ctx context.Context) error {
+	e.log.add("bootstrap " + e.name)
+	e.mu.Lock()
+	delay, err := e.bootstrapDelay, e.bootstrapErr
+	e.mu.Unlock()
+	if waitErr := e.wait(ctx, delay); waitErr != nil {
+		return waitErr
+	}
+	return err
+}
+
+func (e *stubEntry) InterruptWithError(ctx context.Context) error {
+	e.log.add("interrupt " + e.name)
+	e.mu.Lock()
+	delay, err := e.interruptDelay, e.interruptErr
+	e.mu.Unlock()
+	if waitErr := e.wait(ctx, delay); waitErr != nil {
+		return waitErr
+	}
+	return err
+}
+
+func (e *stubEntry) set(f func(e *stubEntry)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	f(e)
+}
+
+func (e *stubEntry) Bootstrap(ctx context.Context) { _ = e.BootstrapWithError(ctx) }
+func (e *stubEntry) Interrupt(ctx context.Context) { _ = e.InterruptWithError(ctx) }
+func (e *stubEntry) BootstrapOrder() int           { return e.order }
+func (e *stubEntry) GetName() string               { return e.name }
+func (e *stubEntry) GetType() string               { return "stub-entry" }
+func (e *stubEntry) GetDescription() string        { return "stub entry " + e.name }
+func (e *stubEntry) String() string                { return e.name }
+
+// newTestBoot creates a Boot without config nor startup signals, with the given
+// Entries added. The Entries are removed from GlobalAppCtx when the test ends.
+func newTestBoot(t *testing.T, entries []Entry, opts ...BootOption) *Boot {
+	t.Helper()
+	opts = append([]BootOption{WithConfigRaw([]byte("{}")), WithStartupSignals()}, opts...)
+	boot := NewBoot(opts...)
+	for _, entry := range entries {
+		if err := boot.AddEntry(context.Background(), entry); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { GlobalAppCtx.RemoveEntry(entry.GetType(), entry.GetName()) })
+	}
+	return boot
+}
+
+// resultSummaries formats results as "<name> <outcome>".
+func resultSummaries(results []EntryResult) []string {
+	summaries := make([]string, len(results))
+	for i, r := range results {
+		summaries[i] = fmt.Sprintf("%s %s", r.Name, r.Outcome)
+	}
+	return summaries
+}
+
+func TestShutdownOnlyInterruptsBootstrappedEntries(t *testing.T) {
+	log := &callLog{}
+	ok := &stubEntry{name: "ok", log: log}
+	broken := &stubEntry{name: "broken", order: 1, log: log, bootstrapErr: errors.New("port in use")}
+	boot := newTestBoot(t, []Entry{ok, broken})
+
+	var bootErr *BootstrapError
+	if err := boot.Bootstrap(context.Background()); !errors.As(err, &bootErr) {
+		t.Fatalf("Bootstrap = %v, want a BootstrapError", err)
+	}
+	if got := resultSummaries(bootErr.Failed); !equalStrings(got, []string{"broken error"}) {
+		t.Errorf("failed = %v", got)
+	}
+
+	report := boot.Shutdown(context.Background())
+	if got := resultSummaries(report.Entries); !equalStrings(got, []string{"ok ok"}) {
+		t.Errorf("interrupted = %v, want only ok", got)
+	}
+	if got, want := log.get(), []string{"bootstrap ok", "bootstrap broken", "interrupt ok"}; !equalStrings(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestEntryOperationsSkipInterruptOfEntriesNotBootstrapped(t *testing.T) {
+	log := &callLog{}
+	broken := &stubEntry{name: "broken", log: log, bootstrapErr: errors.New("port in use")}
+	other := &stubEntry{name: "other", log: log, bootstrapErr: errors.New("port in use")}
+	boot := newTestBoot(t, []Entry{broken, other})
+	_ = boot.Bootstrap(context.Background())
+
+	// Restarting an Entry that failed to bootstrap only bootstraps it
+	broken.set(func(e *stubEntry) { e.bootstrapErr = nil })
+	if err := boot.RestartEntry(context.Background(), "stub-entry", "broken"); err != nil {
+		t.Fatalf("RestartEntry = %v", err)
+	}
+	// Removing one doesn't interrupt it
+	if err := boot.RemoveEntry(context.Background(), "stub-entry", "other"); err != nil {
+		t.Fatalf("RemoveEntry = %v", err)
+	}
+	want := []string{"bootstrap broken", "bootstrap other", "bootstrap broken"}
+	if got := log.get(); !equalStrings(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+
+	// The restarted Entry is interrupted on shutdown
+	if got := resultSummaries(boot.Shutdown(context.Background()).Entries); !equalStrings(got, []string{"broken ok"}) {
+		t.Errorf("interrupted = %v", got)
+	}
+}
+
+// removableEntry is a stubEntry implementing RemovableEntry and ReloadConfigEntry.
+type removableEntry struct {
+	*stubEntry
+	removeErr error
+}
+
+func (e *removableEntry) Remove() error {
+	e.log.add("remove " + e.name)
+	return e.removeErr
+}
+
+func (e *removableEntry) ReloadConfig(ctx context.Context, raw []byte) error {
+	e.log.add("reload " + e.name + " " + string(raw))
+	return nil
+}
+
+func TestEntryManagement(t *testing.T) {
+	log := &callLog{}
+	first := &stubEntry{name: "first", log: log}
+	boot := newTestBoot(t, []Entry{first})
+	ctx := context.Background()
+
+	// Added before Bootstrap: started by it
+	if got := log.get(); len(got) != 0 {
+		t.Fatalf("calls before Bootstrap = %v", got)
+	}
+	if err := boot.Bootstrap(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Added after Bootstrap: started right away
+	second := &removableEntry{stubEntry: &stubEntry{name: "second", log: log}}
+	if err := boot.AddEntry(ctx, second); err != nil {
+		t.Fatalf("AddEntry = %v", err)
+	}
+	defer GlobalAppCtx.RemoveEntry("stub-entry", "second")
+	if err := boot.AddEntry(ctx, &stubEntry{name: "second", log: log}); err == nil {
+		t.Error("AddEntry of a taken name succeeded")
+	}
+
+	if err := boot.RestartEntry(ctx, "stub-entry", "first"); err != nil {
+		t.Fatalf("RestartEntry = %v", err)
+	}
+	if err := boot.ReloadEntry(ctx, "stub-entry", "second", []byte("v2")); err != nil {
+		t.Fatalf("ReloadEntry = %v", err)
+	}
+	if err := boot.ReloadEntry(ctx, "stub-entry", "first", []byte("v2")); err == nil {
+		t.Error("ReloadEntry of an Entry without ReloadConfig succeeded")
+	}
+	if err := boot.RemoveEntry(ctx, "stub-entry", "second"); err != nil {
+		t.Fatalf("RemoveEntry = %v", err)
+	}
+	if boot.GetEntry("stub-entry", "second") != nil || GlobalAppCtx.GetEntry("stub-entry", "second") != nil {
+		t.Error("removed Entry still registered")
+	}
+
+	want := []string{
+		"bootstrap first",
+		"bootstrap second",
+		"interrupt first", "bootstrap first",
+		"reload second v2",
+		"interrupt second", "remove second",
+	}
+	if got := log.get(); !equalStrings(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+
+	boot.Shutdown(ctx)
+	if err := boot.AddEntry(ctx, &stubEntry{name: "late", log: log}); !errors.Is(err, ErrBootShutDown) {
+		t.Errorf("AddEntry after Shutdown = %v, want ErrBootShutDown", err)
+	}
+	if err := boot.RestartEntry(ctx, "stub-entry", "first"); !errors.Is(err, ErrBootShutDown) {
+		t.Errorf("RestartEntry after Shutdown = %v, want ErrBootShutDown", err)
+	}
+}
+
+// errInstanceActive stands for registry.ErrInstanceActive, returned when removing
+// an instance that is still stopping.
+var errInstanceActive = errors.New("instance is active")
+
+func TestRemoveEntryKeepsEntryThatDidNotStop(t *testing.T) {
+	log := &callLog{}
+	entry := &removableEntry{stubEntry: &stubEntry{name: "stuck", log: log, interruptDelay: time.Second}}
+	boot := newTestBoot(t, []Entry{entry}, WithEntryShutdownTimeout(20*time.Millisecond))
+	ctx := context.Background()
+	if err := boot.Bootstrap(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// The interrupt times out: the Entry is neither released nor unregistered
+	if err := boot.RemoveEntry(ctx, "stub-entry", "stuck"); err == nil {
+		t.Fatal("RemoveEntry of a stuck Entry succeeded")
+	}
+	if boot.GetEntry("stub-entry", "stuck") == nil || GlobalAppCtx.GetEntry("stub-entry", "stuck") == nil {
+		t.Fatal("Entry unregistered although its interrupt timed out")
+	}
+
+	// Remove fails: still registered
+	entry.set(func(e *stubEntry) { e.interruptDelay = 0 })
+	entry.removeErr = errInstanceActive
+	if err := boot.RemoveEntry(ctx, "stub-entry", "stuck"); !errors.Is(err, errInstanceActive) {
+		t.Fatalf("RemoveEntry = %v, want errInstanceActive", err)
+	}
+	if boot.GetEntry("stub-entry", "stuck") == nil {
+		t.Fatal("Entry unregistered although Remove failed")
+	}
+
+	// The retry only releases it, it's already interrupted
+	entry.removeErr = nil
+	if err := boot.RemoveEntry(ctx, "stub-entry", "stuck"); err != nil {
+		t.Fatalf("RemoveEntry = %v", err)
+	}
+	if boot.GetEntry("stub-entry", "stuck") != nil {
+		t.Error("Entry still registered")
+	}
+	want := []string{"bootstrap stuck", "interrupt stuck", "interrupt stuck", "remove stuck", "remove stuck"}
+	if got := log.get(); !equalStrings(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+// drainingEntry is a stubEntry implementing Drainer.
+type drainingEntry struct {
+	*stubEntry
+	boot       *Boot
+	drainDelay time.Duration
+	readyWhile atomic.Bool // Boot.Ready during Drain
+}
+
+func (e *drainingEntry) Drain(ctx context.Context) error {
+	e.log.add("drain " + e.name)
+	e.readyWhile.Store(e.boot.Ready())
+	return e.wait(ctx, e.drainDelay)
+}
+
+func TestShutdownDrainHasItsOwnBudget(t *testing.T) {
+	log := &callLog{}
+	entry := &drainingEntry{stubEntry: &stubEntry{name: "slow", log: log}, drainDelay: 80 * time.Millisecond}
+	boot := newTestBoot(t, []Entry{entry},
+		WithDrainPeriod(20*time.Millisecond), WithDrainTimeout(time.Second), WithShutdownTimeout(50*time.Millisecond))
+	entry.boot = boot
+	GlobalAppCtx.AddShutdownHookWithContext("drain-test", 0, func(ctx context.Context) error {
+		log.add("hook")
+		return ctx.Err()
+	})
+	defer GlobalAppCtx.RemoveShutdownHook("drain-test")
+
+	if boot.Ready() {
+		t.Error("ready before Bootstrap")
+	}
+	if err := boot.Bootstrap(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !boot.Ready() {
+		t.Error("not ready after Bootstrap")
+	}
+
+	// The drain outlasts the ShutdownTimeout, which only starts once it ends
+	report := boot.Shutdown(context.Background())
+	if entry.readyWhile.Load() || boot.Ready() {
+		t.Error("ready during shutdown")
+	}
+	if got := resultSummaries(report.Drained); !equalStrings(got, []string{"slow ok"}) {
+		t.Errorf("drained = %v", got)
+	}
+	if got := resultSummaries(report.Hooks); !equalStrings(got, []string{"drain-test ok"}) {
+		t.Errorf("hooks = %v", got)
+	}
+	if got := resultSummaries(report.Entries); !equalStrings(got, []string{"slow ok"}) {
+		t.Errorf("interrupted = %v", got)
+	}
+	if got, want := log.get(), []string{"bootstrap slow", "drain slow", "hook", "interrupt slow"}; !equalStrings(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestShutdownDrainTimeout(t *testing.T) {
+	log := &callLog{}
+	entry := &drainingEntry{stubEntry: &stubEntry{name: "stuck", log: log}, drainDelay: time.Second}
+	boot := newTestBoot(t, []Entry{entry}, WithDrainTimeout(20*time.Millisecond))
+	entry.boot = boot
+	if err := boot.Bootstrap(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	report := boot.Shutdown(context.Background())
+	if got := resultSummaries(report.Drained); !equalStrings(got, []string{"stuck timeout"}) {
+		t.Errorf("drained = %v", got)
+	}
+	// A failed drain doesn't keep the Entry from being interrupted
+	if got := resultSummaries(report.Entries); !equalStrings(got, []string{"stuck ok"}) {
+		t.Errorf("interrupted = %v", got)
+	}
+}
+
+func TestBootstrapEntryTimeout(t *testing.T) {
+	log := &callLog{}
+	stuck := &stubEntry{name: "stuck", log: log, bootstrapDelay: time.Second}
+	next := &stubEntry{name: "next", order: 1, log: log}
+	boot := newTestBoot(t, []Entry{stuck, next}, WithEntryBootstrapTimeout(20*time.Millisecond))
+
+	var bootErr *BootstrapError
+	if err := boot.Bootstrap(context.Background()); !errors.As(err, &bootErr) {
+		t.Fatalf("Bootstrap = %v, want a BootstrapError", err)
+	}
+	// The stuck Entry is abandoned, the next one still starts
+	if bootErr.Cause != nil {
+		t.Errorf("cause = %v, want nil", bootErr.Cause)
+	}
+	if got := resultSummaries(boot.BootstrapResults()); !equalStrings(got, []string{"stuck timeout", "next ok"}) {
+		t.Errorf("results = %v", got)
+	}
+	if failed := bootErr.Failed; len(failed) != 1 || !strings.Contains(failed[0].Error, "stuck in Bootstrap") {
+		t.Errorf("failed = %+v", failed)
+	}
+	if boot.Ready() {
+		t.Error("ready after an incomplete Bootstrap")
+	}
+}
+
+func TestBootstrapStartupTimeoutCancelsRemainingEntries(t *testing.T) {
+	log := &callLog{}
+	slow := &stubEntry{name: "slow", log: log, bootstrapDelay: time.Second}
+	later := &stubEntry{name: "later", order: 1, log: log}
+	boot := newTestBoot(t, []Entry{slow, later}, WithStartupTimeout(20*time.Millisecond))
+
+	var bootErr *BootstrapError
+	if err := boot.Bootstrap(context.Background()); !errors.As(err, &bootErr) {
+		t.Fatalf("Bootstrap = %v, want a BootstrapError", err)
+	}
+	if !errors.Is(bootErr.Cause, context.DeadlineExceeded) {
+		t.Errorf("cause = %v, want DeadlineExceeded", bootErr.Cause)
+	}
+	if got := resultSummaries(bootErr.Failed); !equalStrings(got, []string{"slow cancelled", "later cancelled"}) {
+		t.Errorf("failed = %v", got)
+	}
+	if got := log.get(); !equalStrings(got, []string{"bootstrap slow"}) {
+		t.Errorf("calls = %v", got)
+	}
+}
+
+// barrierEntry is a stubEntry whose bootstrap waits until every Entry sharing its
+// barrier started bootstrapping, so it only succeeds if they run concurrently.
+type barrierEntry struct {
+	*stubEntry
+	barrier *sync.WaitGroup
+}
+
+func (e *barrierEntry) BootstrapWithError(ctx context.Context) error {
+	e.log.add("bootstrap " + e.name)
+	e.barrier.Done()
+	all := make(chan struct{})
+	go func() {
+		e.barrier.Wait()
+		close(all)
+	}()
+	select {
+	case <-all:
+		return nil
+	case <-ctx.Done():
+		return errors.New("bootstrapped alone")
+	}
+}
+
+func TestParallelBootstrapKeepsStageAndOrder(t *testing.T) {
+	log := &callLog{}
+	var barrier sync.WaitGroup
+	barrier.Add(2)
+	instance, plugin := newStubInstance("plugin")
+	plugin.onCall = log.add
+	pluginEntry := NewPluginEntry("plugin", instance, true)
+	pluginEntry.SetBootstrapOrder(5) // Plugin Entries still start before user Entries
+	boot := newTestBoot(t, []Entry{
+		&stubEntry{name: "last", order: 1, log: log},
+		&barrierEntry{stubEntry: &stubEntry{name: "a", log: log}, barrier: &barrier},
+		&barrierEntry{stubEntry: &stubEntry{name: "b", log: log}, barrier: &barrier},
+		pluginEntry,
+	}, WithParallelBootstrap(4), WithEntryBootstrapTimeout(time.Second))
+
+	if err := boot.Bootstrap(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	calls := log.get()
+	if len(calls) != 4 || calls[0] != "plugin start" || calls[3] != "bootstrap last" {
+		t.Fatalf("calls = %v, want the plugin first and last at the end", calls)
+	}
+	if middle := calls[1] + ", " + calls[2]; middle != "bootstrap a, bootstrap b" && middle != "bootstrap b, bootstrap a" {
+		t.Errorf("calls = %v", calls)
+	}
+	// Results are reported in bootstrap order whatever the completion order
+	if got := resultSummaries(boot.BootstrapResults()); !equalStrings(got, []string{"plugin ok", "a ok", "b ok", "last ok"}) {
+		t.Errorf("results = %v", got)
+	}
+}
+
+// addRecordingShutdownHook adds a shutdown hook logging its name, removed when the test ends.
+func addRecordingShutdownHook(t *testing.T, log *callLog, name string, priority int, opts ...ShutdownHookOption) {
+	t.Helper()
+	GlobalAppCtx.AddShutdownHookWithContext(name, priority, func(ctx context.Context) error {
+		log.add("hook " + name)
+		return nil
+	}, opts...)
+	t.Cleanup(func() { GlobalAppCtx.RemoveShutdownHook(name) })
+}
+
+func TestShutdownHookOrder(t *testing.T) {
+	log := &callLog{}
+	boot := newTestBoot(t, []Entry{&stubEntry{name: "entry", log: log}})
+	addRecordingShutdownHook(t, log, "late", 10)
+	addRecordingShutdownHook(t, log, "replaced", -10)
+	addRecordingShutdownHook(t, log, "early", -10)
+	addRecordingShutdownHook(t, log, "close-pool", 0, WithShutdownHookAfterEntries())
+	addRecordingShutdownHook(t, log, "flush-metrics", -1, WithShutdownHookAfterEntries())
+	// Replacing a hook keeps its registration position among equal priorities
+	addRecordingShutdownHook(t, log, "replaced", -10)
+
+	wantNames := []string{"replaced", "early", "flush-metrics", "close-pool", "late"}
+	if got := GlobalAppCtx.ShutdownHookNames(); !equalStrings(got, wantNames) {
+		t.Errorf("hook names = %v, want %v", got, wantNames)
+	}
+	if err := boot.Bootstrap(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	boot.Shutdown(context.Background())
+
+	want := []string{
+		"bootstrap entry",
+		"hook replaced", "hook early", "hook late",
+		"interrupt entry",
+		"hook flush-metrics", "hook close-pool",
+	}
+	if got := log.get(); !equalStrings(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestShutdownHookTimeout(t *testing.T) {
+	log := &callLog{}
+	boot := newTestBoot(t, nil, WithDefaultShutdownHookTimeout(time.Second))
+	GlobalAppCtx.AddShutdownHookWithContext("stuck", 0, func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(time.Second) // Ignores its context
+		return nil
+	}, WithShutdownHookTimeout(20*time.Millisecond))
+	defer GlobalAppCtx.RemoveShutdownHook("stuck")
+	addRecordingShutdownHook(t, log, "next", 1)
+
+	begin := time.Now()
+	report := boot.Shutdown(context.Background())
+	if elapsed := time.Since(begin); elapsed > 500*time.Millisecond {
+		t.Errorf("Shutdown took %v, the stuck hook wasn't abandoned", elapsed)
+	}
+	if got := resultSummaries(report.Hooks); !equalStrings(got, []string{"stuck timeout", "next ok"}) {
+		t.Errorf("hooks = %v", got)
+	}
+	if got := log.get(); !equalStrings(got, []string{"hook next"}) {
+		t.Errorf("calls = %v", got)
+	}
+}
+
+func TestShutdownReport(t *testing.T) {
+	log := &callLog{}
+	instance, _ := newStubInstance("plugin")
+	boot := newTestBoot(t, []Entry{
+		NewPluginEntry("plugin", instance, true),
+		&stubEntry{name: "failing", log: log, interruptErr: errors.New("flush failed")},
+		&stubEntry{name: "stuck", log: log, interruptDelay: time.Second},
+	}, WithEntryShutdownTimeout(20*time.Millisecond))
+	GlobalAppCtx.AddShutdownHookWithContext("broken-hook", 0, func(ctx context.Context) error {
+		return errors.New("close failed")
+	})
+	defer GlobalAppCtx.RemoveShutdownHook("broken-hook")
+	if boot.ShutdownReport() != nil {
+		t.Error("report before Shutdown")
+	}
+	if err := boot.Bootstrap(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	report := boot.Shutdown(context.Background())
+	if boot.ShutdownReport() != report {
+		t.Error("ShutdownReport doesn't return the last report")
+	}
+	// User Entries are interrupted before plugin Entries
+	if got := resultSummaries(report.Entries); !equalStrings(got, []string{"failing error", "stuck timeout", "plugin ok"}) {
+		t.Errorf("entries = %v", got)
+	}
+	if got := resultSummaries(report.Hooks); !equalStrings(got, []string{"broken-hook error"}) {
+		t.Errorf("hooks = %v", got)
+	}
+	if got := resultSummaries(report.Abandoned); !equalStrings(got, []string{"stuck timeout"}) {
+		t.Errorf("abandoned = %v", got)
+	}
+	if report.ExitCode != ShutdownExitAbandoned {
+		t.Errorf("exit code = %d, want %d", report.ExitCode, ShutdownExitAbandoned)
+	}
+	if report.Duration <= 0 {
+		t.Errorf("duration = %v", report.Duration)
+	}
+	err := report.Err()
+	if err == nil || !strings.Contains(err.Error(), "flush failed") || !strings.Contains(err.Error(), "close failed") {
+		t.Errorf("Err = %v", err)
+	}
+	for _, want := range []string{"3 entries, 1 hooks, 1 abandoned, exit code 2", "[stub-entry] stuck: timeout"} {
+		if !strings.Contains(report.String(), want) {
+			t.Errorf("String = %q, missing %q", report.String(), want)
+		}
+	}
+
+	raw, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded ShutdownReport
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.ExitCode != report.ExitCode || len(decoded.Entries) != 3 || decoded.Hooks[0].Error != "close failed" {
+		t.Errorf("JSON round trip = %s", raw)
+	}
+}
+
+func TestCleanShutdownReport(t *testing.T) {
+	boot := newTestBoot(t, []Entry{&stubEntry{name: "entry", log: &callLog{}}})
+	if err := boot.Bootstrap(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	report := boot.Shutdown(context.Background())
+	if report.ExitCode != ShutdownExitOK || report.Err() != nil || len(report.Abandoned) != 0 {
+		t.Errorf("report = %s", report)
+	}
+}
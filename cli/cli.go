@@ -0,0 +1,289 @@
+// Package cli runs the pluggo commands that need the host's plugin factories,
+// from the host binary itself so every factory it imports is registered.
+//
+//	func main() {
+//		// handles `myapp config validate boot.yaml`
+//		if handled, err := cli.RunCLI(os.Args[1:]); handled {
+//			os.Exit(cli.ExitCode(err))
+//		}
+//
+//		boot := plugGo.NewBoot()
+//		...
+//	}
+//
+// Commands:
+//
+//	config validate [-print] [-allow section,...] <boot.yaml>   report problems, exit 1 if any
+//	config print [-instance name] [-secrets] <boot.yaml>         print the effective config per instance
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/seencxy/plugGo/registry"
+	"gopkg.in/yaml.v3"
+)
+
+// Exit codes of Run.
+const (
+	ExitOK      = 0 // Success
+	ExitInvalid = 1 // The config has problems
+	ExitUsage   = 2 // Bad command line or unreadable file
+)
+
+// Option is a CLI configuration option function.
+type Option func(*options)
+
+type options struct {
+	sections []string
+	command  string
+}
+
+// defaultCommand is the subcommand handled by Run and RunCLI.
+const defaultCommand = "config"
+
+// WithSections declares top-level sections consumed by user Entries,
+// so validate doesn't report them as unknown.
+func WithSections(sections ...string) Option {
+	return func(o *options) {
+		o.sections = append(o.sections, sections...)
+	}
+}
+
+// WithCommand renames the subcommand (default "config"), e.g. when the host
+// already has a "config" command of its own.
+func WithCommand(name string) Option {
+	return func(o *options) {
+		o.command = name
+	}
+}
+
+// ExitError is returned by RunCLI when a command fails.
+type ExitError struct {
+	Code int // ExitInvalid or ExitUsage
+}
+
+// Error describes the failure.
+func (e *ExitError) Error() string {
+	if e.Code == ExitInvalid {
+		return "config has problems"
+	}
+	return "invalid command line"
+}
+
+// ExitCode returns the process exit code for an error of RunCLI: ExitOK for nil,
+// the Code of an *ExitError, ExitUsage otherwise.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitUsage
+}
+
+// RunCLI runs a pluggo command if args start with one (args[0] is "config", see
+// WithCommand), writing to os.Stdout and os.Stderr. It never exits the process:
+// the host decides what to do, typically os.Exit(ExitCode(err)) when handled.
+//
+// Parameters:
+//   - args: command line without the program name, usually os.Args[1:]
+//   - opts: WithSections, WithCommand
+//
+// Returns:
+//   - bool: whether args were a pluggo command; if false the host starts normally
+//   - error: nil if the command succeeded, otherwise an *ExitError
+func RunCLI(args []string, opts ...Option) (bool, error) {
+	o := newOptions(opts)
+	if len(args) == 0 || args[0] != o.command {
+		return false, nil
+	}
+	if code := Run(args, os.Stdout, os.Stderr, opts...); code != ExitOK {
+		return true, &ExitError{Code: code}
+	}
+	return true, nil
+}
+
+// newOptions applies option functions over the defaults.
+func newOptions(opts []Option) *options {
+	o := &options{command: defaultCommand}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Run runs a pluggo command and returns its exit code.
+//
+// Parameters:
+//   - args: command line without the program name, e.g. ["config", "validate", "boot.yaml"]
+//   - stdout: receives reports and configs
+//   - stderr: receives usage errors
+//
+// Returns:
+//   - int: ExitOK, ExitInvalid or ExitUsage
+func Run(args []string, stdout, stderr io.Writer, opts ...Option) int {
+	o := newOptions(opts)
+	if len(args) < 2 || args[0] != o.command {
+		fmt.Fprintf(stderr, "usage: %s validate|print [flags] <boot.yaml>\n", o.command)
+		return ExitUsage
+	}
+	switch args[1] {
+	case "validate":
+		return runValidate(args[2:], stdout, stderr, o)
+	case "print":
+		return runPrint(args[2:], stdout, stderr, o)
+	default:
+		fmt.Fprintf(stderr, "unknown %s command %q (want validate or print)\n", o.command, args[1])
+		return ExitUsage
+	}
+}
+
+func runValidate(args []string, stdout, stderr io.Writer, o *options) int {
+	flags := flag.NewFlagSet(o.command+" validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	printConfig := flags.Bool("print", false, "also print the effective config of each instance")
+	allow := flags.String("allow", "", "comma-separated sections consumed by user Entries")
+	path, raw, ok := parseFileArgs(flags, args, stderr)
+	if !ok {
+		return ExitUsage
+	}
+
+	report := registry.LintConfig(raw, append(o.sections, splitList(*allow)...)...)
+	if *printConfig {
+		printInstances(stdout, report.Instances, "", false)
+	}
+	for _, issue := range report.Issues {
+		fmt.Fprintf(stdout, "%s:%s\n", path, issue)
+	}
+	if !report.OK() {
+		fmt.Fprintf(stdout, "%s: %d problem(s)\n", path, len(report.Issues))
+		return ExitInvalid
+	}
+	fmt.Fprintf(stdout, "%s: OK (%d instances)\n", path, len(report.Instances))
+	return ExitOK
+}
+
+func runPrint(args []string, stdout, stderr io.Writer, o *options) int {
+	flags := flag.NewFlagSet(o.command+" print", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	instance := flags.String("instance", "", "only print this instance")
+	secrets := flags.Bool("secrets", false, "print passwords, secrets and tokens instead of masking them")
+	path, raw, ok := parseFileArgs(flags, args, stderr)
+	if !ok {
+		return ExitUsage
+	}
+
+	report := registry.LintConfig(raw, o.sections...)
+	printInstances(stdout, report.Instances, *instance, *secrets)
+	for _, issue := range report.Issues {
+		fmt.Fprintf(stderr, "%s:%s\n", path, issue)
+	}
+	if !report.OK() {
+		return ExitInvalid
+	}
+	return ExitOK
+}
+
+// parseFileArgs parses the flags and reads the single boot.yaml argument.
+func parseFileArgs(flags *flag.FlagSet, args []string, stderr io.Writer) (string, []byte, bool) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return "", nil, false
+		}
+		if flags.NArg() == 0 {
+			break
+		}
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+	if len(positional) != 1 {
+		fmt.Fprintf(stderr, "usage: %s [flags] <boot.yaml>\n", flags.Name())
+		return "", nil, false
+	}
+
+	raw, err := os.ReadFile(positional[0])
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return "", nil, false
+	}
+	return positional[0], raw, true
+}
+
+// printInstances writes the effective config of each instance as YAML.
+func printInstances(w io.Writer, instances []registry.InstanceConfig, only string, secrets bool) {
+	for _, inst := range instances {
+		if only != "" && inst.Name != only {
+			continue
+		}
+
+		var node yaml.Node
+		if err := node.Encode(inst.Config); err != nil {
+			fmt.Fprintf(w, "# %s/%s: %v\n", inst.PluginType, inst.Name, err)
+			continue
+		}
+		if !secrets {
+			maskSecrets(&node)
+		}
+		data, err := yaml.Marshal(&node)
+		if err != nil {
+			fmt.Fprintf(w, "# %s/%s: %v\n", inst.PluginType, inst.Name, err)
+			continue
+		}
+
+		state := "enabled"
+		if !inst.Enabled {
+			state = "disabled"
+		}
+		if len(inst.Labels) > 0 {
+			state += ", labels " + registry.SelectorFromLabels(inst.Labels).String()
+		}
+		fmt.Fprintf(w, "# %s/%s (line %d, %s)\n%s\n", inst.PluginType, inst.Name, inst.Line, state, data)
+	}
+}
+
+// secretKeys are substrings of keys whose values are masked when printing.
+var secretKeys = []string{"password", "secret", "token", "apikey", "authorization"}
+
+// maskSecrets replaces non-empty scalar values of secret-looking keys.
+func maskSecrets(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if value.Kind == yaml.ScalarNode && value.Value != "" && isSecretKey(key.Value) {
+				value.Value, value.Tag, value.Style = "******", "!!str", 0
+			}
+		}
+	}
+	for _, child := range node.Content {
+		maskSecrets(child)
+	}
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range secretKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitList(s string) []string {
+	var result []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
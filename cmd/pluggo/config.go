@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// configMain is the program generated to run `config` commands with the host's factories.
+var configMain = template.Must(template.New("main").Parse(`// Code generated by pluggo. DO NOT EDIT.
+
+package main
+
+import (
+	"os"
+
+	"github.com/seencxy/plugGo/cli"
+{{range .}}
+	_ {{printf "%q" .}}{{end}}
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+}
+`))
+
+// exitError carries the exit code of a command run on the user's behalf.
+type exitError struct {
+	code int
+}
+
+func (e *exitError) Error() string {
+	return "exit status " + strconv.Itoa(e.code)
+}
+
+// runConfig runs `config validate|print` in a generated program importing the plugin
+// packages given with --import, so their factories are registered.
+// Hosts that call cli.RunCLI(os.Args[1:]) can instead run `go run ./cmd/app config validate boot.yaml`.
+func runConfig(args []string) error {
+	var imports, forward []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--import" || arg == "-import":
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s requires a package path", arg)
+			}
+			imports = append(imports, args[i+1])
+			i++
+		case strings.HasPrefix(arg, "--import=") || strings.HasPrefix(arg, "-import="):
+			imports = append(imports, arg[strings.IndexByte(arg, '=')+1:])
+		default:
+			forward = append(forward, arg)
+		}
+	}
+	if len(imports) == 0 {
+		return fmt.Errorf("no plugin packages to check the config against: " +
+			"pass --import <package> for each plugin, or call cli.RunCLI(os.Args[1:]) in your main and run `go run ./yourapp config ...`")
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	root, err := moduleRoot(wd)
+	if err != nil {
+		return err
+	}
+
+	// The program must live inside the module to resolve its imports;
+	// dot directories are ignored by ./... patterns
+	dir, err := os.MkdirTemp(root, ".pluggo-config-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	f, err := os.Create(filepath.Join(dir, "main.go"))
+	if err != nil {
+		return err
+	}
+	if err := configMain.Execute(f, imports); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	bin := filepath.Join(dir, "pluggo-config")
+	build := exec.Command("go", "build", "-o", bin, dir)
+	build.Stdout, build.Stderr = os.Stderr, os.Stderr
+	if err := build.Run(); err != nil {
+		return fmt.Errorf("failed to build the config checker: %w", err)
+	}
+
+	cmd := exec.Command(bin, append([]string{"config"}, forward...)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return &exitError{code: exitErr.ExitCode()}
+		}
+		return err
+	}
+	return nil
+}
+
+// moduleRoot returns the directory of the go.mod enclosing dir.
+func moduleRoot(start string) (string, error) {
+	for dir := start; ; {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no go.mod found above %s", start)
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,73 @@
+// Command pluggo is the plugGo developer tool.
+//
+//	pluggo new plugin <name> [--module path] [--dir dir] [--boot boot.yaml]
+//	pluggo config validate --import <plugin package>... [-print] boot.yaml
+//	pluggo config print --import <plugin package>... [-instance name] boot.yaml
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+const usage = `pluggo is the plugGo developer tool.
+
+Usage:
+  pluggo new plugin <name> [flags]                       scaffold a plugin from the plugGo template
+  pluggo config validate --import <pkg>... <boot.yaml>   check a boot.yaml against your plugins
+  pluggo config print --import <pkg>... <boot.yaml>      print the effective config per instance
+
+Run "pluggo <command> -h" for the flags of a command.
+`
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
+		fmt.Fprintln(os.Stderr, "pluggo:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("missing command")
+	}
+
+	switch args[0] {
+	case "new":
+		if len(args) < 2 || args[1] != "plugin" {
+			return fmt.Errorf("usage: pluggo new plugin <name> [flags]")
+		}
+		return runNewPlugin(args[2:])
+	case "config":
+		return runConfig(args[1:])
+	case "help", "-h", "-help", "--help":
+		fmt.Print(usage)
+		return nil
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// parseArgs parses flags that may come before or after positional arguments
+// (the flag package stops at the first positional one).
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/seencxy/plugGo"
+	plugGoConfig "github.com/seencxy/plugGo/config"
+)
+
+//go:embed templates/*.tmpl
+var extraTemplates embed.FS
+
+const (
+	// templateDir is the directory of the template sources in plugGo.TemplateFiles
+	templateDir = "template"
+
+	// templateImport is the import path of the template config package
+	templateImport = "github.com/seencxy/plugGo/template/config"
+)
+
+// pluginNamePattern matches plugin type names (the boot.yaml section key).
+var pluginNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// scaffold describes the plugin being generated.
+type scaffold struct {
+	Name       string // Plugin type name (PluginName, boot.yaml key)
+	Package    string // Go package name
+	ImportPath string // Import path of the plugin package
+	Dir        string // Output directory
+}
+
+func runNewPlugin(args []string) error {
+	flags := flag.NewFlagSet("new plugin", flag.ContinueOnError)
+	module := flags.String("module", "", "import path of the new plugin package (default: derived from the enclosing go.mod)")
+	dir := flags.String("dir", "", "output directory (default: ./<package>)")
+	pkg := flags.String("package", "", "Go package name (default: <name> without '-' and '_')")
+	bootPath := flags.String("boot", "", "boot.yaml to add an instance section to")
+	force := flags.Bool("force", false, "write into a non-empty output directory, overwriting files")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: pluggo new plugin <name> [flags]")
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected exactly one plugin name")
+	}
+
+	s, err := newScaffold(positional[0], *pkg, *dir, *module)
+	if err != nil {
+		return err
+	}
+	if err := s.checkDir(*force); err != nil {
+		return err
+	}
+	if err := s.render(); err != nil {
+		return err
+	}
+	fmt.Printf("Created plugin %q (package %s) in %s\n", s.Name, s.Package, s.Dir)
+
+	if *bootPath != "" {
+		if err := s.addBootSection(*bootPath); err != nil {
+			return err
+		}
+		fmt.Printf("Added section %q to %s\n", s.Name, *bootPath)
+	}
+
+	fmt.Printf("\nImport it in your main package to register the factory:\n\n\timport _ %q\n\n", s.ImportPath)
+	return nil
+}
+
+// newScaffold validates the names and resolves the package, directory and import path.
+func newScaffold(name, pkg, dir, module string) (*scaffold, error) {
+	if !pluginNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid plugin name %q: use lowercase letters, digits, '-' and '_', starting with a letter", name)
+	}
+	if pkg == "" {
+		pkg = strings.NewReplacer("-", "", "_", "").Replace(name)
+	}
+	if !token.IsIdentifier(pkg) || pkg == "main" {
+		return nil, fmt.Errorf("invalid package name %q", pkg)
+	}
+	if dir == "" {
+		dir = pkg
+	}
+
+	if module == "" {
+		var err error
+		if module, err = importPathOf(dir); err != nil {
+			return nil, fmt.Errorf("%w; pass --module", err)
+		}
+	}
+
+	return &scaffold{Name: name, Package: pkg, ImportPath: module, Dir: dir}, nil
+}
+
+// importPathOf derives the import path of dir from the go.mod of an enclosing directory.
+func importPathOf(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	root, err := moduleRoot(filepath.Dir(abs))
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	modulePath := modulePathOf(data)
+	if modulePath == "" {
+		return "", fmt.Errorf("no module directive in %s", filepath.Join(root, "go.mod"))
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(modulePath, filepath.ToSlash(rel)), nil
+}
+
+// modulePathOf returns the module path declared in a go.mod file.
+func modulePathOf(gomod []byte) string {
+	for _, line := range strings.Split(string(gomod), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
+
+// checkDir refuses to write into a non-empty directory unless forced.
+func (s *scaffold) checkDir(force bool) error {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if len(entries) > 0 && !force {
+		return fmt.Errorf("directory %s is not empty (use --force to overwrite)", s.Dir)
+	}
+	return nil
+}
+
+// render writes the template files, renamed for the plugin, and the starter test.
+func (s *scaffold) render() error {
+	err := fs.WalkDir(plugGo.TemplateFiles, templateDir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := plugGo.TemplateFiles.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		rel := strings.TrimPrefix(name, templateDir+"/")
+		if strings.HasSuffix(name, ".go") {
+			if data, err = s.rewrite(rel, data); err != nil {
+				return err
+			}
+		}
+		return s.writeFile(rel, data)
+	})
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.ParseFS(extraTemplates, "templates/*.tmpl")
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "plugin_test.go.tmpl", s); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format plugin_test.go: %w", err)
+	}
+	return s.writeFile("plugin_test.go", src)
+}
+
+// rewrite renames the package, constants and imports of a template Go file.
+func (s *scaffold) rewrite(name string, src []byte) ([]byte, error) {
+	text := string(src)
+	if path.Dir(name) == "." {
+		text = strings.Replace(text, "package template\n", "package "+s.Package+"\n", 1)
+	}
+	text = strings.ReplaceAll(text, `"`+templateImport+`"`, `"`+s.ImportPath+`/config"`)
+	if name == "consts.go" {
+		text = strings.Replace(text, `PluginName = "template"`, fmt.Sprintf("PluginName = %q", s.Name), 1)
+		text = strings.Replace(text, `LoggerPrefix = "template-%s"`, fmt.Sprintf("LoggerPrefix = %q", s.Name+"-%s"), 1)
+	}
+
+	formatted, err := format.Source([]byte(text))
+	if err != nil {
+		return nil, fmt.Errorf("failed to format %s: %w", name, err)
+	}
+	return formatted, nil
+}
+
+func (s *scaffold) writeFile(name string, data []byte) error {
+	target := filepath.Join(s.Dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(target, data, 0o644)
+}
+
+// addBootSection appends a section with one instance using the template defaults to a boot.yaml.
+func (s *scaffold) addBootSection(bootPath string) error {
+	raw, err := os.ReadFile(bootPath)
+	if err != nil {
+		return err
+	}
+	if plugGoConfig.HasYAMLSection(raw, s.Name) {
+		return fmt.Errorf("%s already has a %q section", bootPath, s.Name)
+	}
+	defaults, err := plugGo.TemplateFiles.ReadFile(templateDir + "/config.yaml")
+	if err != nil {
+		return err
+	}
+
+	var section strings.Builder
+	if len(raw) > 0 && !bytes.HasSuffix(raw, []byte("\n")) {
+		section.WriteString("\n")
+	}
+	fmt.Fprintf(&section, "\n# %s plugin instances\n%s:\n", s.Name, s.Name)
+	first := true
+	for _, line := range strings.Split(string(defaults), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "name:") {
+			line = fmt.Sprintf("name: %q", s.Name+"-1")
+		}
+		if first {
+			section.WriteString("  - " + line + "\n")
+			first = false
+		} else {
+			section.WriteString("    " + line + "\n")
+		}
+	}
+
+	f, err := os.OpenFile(bootPath, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(section.String()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,267 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ReloadTag is the struct tag marking how a field change can be applied.
+// Values: "hot" (can be applied while running) or "restart" (requires restarting
+// the affected component). Untagged fields inherit from their parent and default to "restart".
+//
+//	type Config struct {
+//	    Sources []Source `yaml:"sources"`                // restart
+//	    Filters Filters  `yaml:"filters" reload:"hot"`   // hot, including nested fields
+//	}
+const ReloadTag = "reload"
+
+// ReloadMode describes how a config change can be applied.
+type ReloadMode int
+
+const (
+	// ReloadRestart means the change requires restarting the affected component.
+	ReloadRestart ReloadMode = iota
+	// ReloadHot means the change can be applied without a restart.
+	ReloadHot
+)
+
+// String returns the string representation of ReloadMode.
+func (m ReloadMode) String() string {
+	if m == ReloadHot {
+		return "hot"
+	}
+	return "restart"
+}
+
+// Change is a single changed value between two configs.
+type Change struct {
+	// Path is the location of the value using YAML names, e.g. "sources[0].interval".
+	Path string
+	// Old is the previous value (nil if added).
+	Old interface{}
+	// New is the new value (nil if removed).
+	New interface{}
+	// Mode tells whether the change can be hot-applied.
+	Mode ReloadMode
+}
+
+// String returns string representation.
+func (c Change) String() string {
+	return fmt.Sprintf("%s (%s): %v -> %v", c.Path, c.Mode, c.Old, c.New)
+}
+
+// Diff is the structural difference between two configs.
+type Diff struct {
+	Changes []Change
+}
+
+// Empty reports whether the configs are equal.
+func (d Diff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// Paths returns the paths of all changed values.
+func (d Diff) Paths() []string {
+	paths := make([]string, len(d.Changes))
+	for i, c := range d.Changes {
+		paths[i] = c.Path
+	}
+	return paths
+}
+
+// RequiresRestart reports whether any change is not hot-reloadable.
+func (d Diff) RequiresRestart() bool {
+	for _, c := range d.Changes {
+		if c.Mode == ReloadRestart {
+			return true
+		}
+	}
+	return false
+}
+
+// Changed reports whether the value at path, or anything below it, changed.
+// For example Changed("sources") is true if "sources[1].url" changed.
+func (d Diff) Changed(path string) bool {
+	for _, c := range d.Changes {
+		if c.Path == path || strings.HasPrefix(c.Path, path+".") || strings.HasPrefix(c.Path, path+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// Compare returns the structural difference between two configs of the same type.
+// Pointers are followed, structs compared field by field (exported fields only,
+// including those of inlined embedded structs),
+// slices element by element and maps key by key. Fields inlined with
+// `yaml:",inline"` keep their parent's path; funcs and channels are compared by identity.
+//
+// Parameters:
+//   - oldCfg: previous config
+//   - newCfg: new config
+//
+// Returns:
+//   - Diff: changed paths with their reload mode
+//   - error: returns error if the configs have different types
+func Compare(oldCfg, newCfg interface{}) (Diff, error) {
+	oldV := reflect.ValueOf(oldCfg)
+	newV := reflect.ValueOf(newCfg)
+
+	if oldV.IsValid() && newV.IsValid() && oldV.Type() != newV.Type() {
+		return Diff{}, fmt.Errorf("config type mismatch: %T vs %T", oldCfg, newCfg)
+	}
+
+	var d Diff
+	compareValues(&d, "", oldV, newV, ReloadRestart)
+	return d, nil
+}
+
+// compareValues appends the changes between two values to d.
+func compareValues(d *Diff, path string, oldV, newV reflect.Value, mode ReloadMode) {
+	// Follow pointers and interfaces
+	for oldV.IsValid() && (oldV.Kind() == reflect.Ptr || oldV.Kind() == reflect.Interface) {
+		if oldV.IsNil() {
+			oldV = reflect.Value{}
+			break
+		}
+		oldV = oldV.Elem()
+	}
+	for newV.IsValid() && (newV.Kind() == reflect.Ptr || newV.Kind() == reflect.Interface) {
+		if newV.IsNil() {
+			newV = reflect.Value{}
+			break
+		}
+		newV = newV.Elem()
+	}
+
+	if !oldV.IsValid() || !newV.IsValid() || oldV.Type() != newV.Type() {
+		if oldV.IsValid() || newV.IsValid() {
+			d.Changes = append(d.Changes, Change{Path: rootPath(path), Old: valueOf(oldV), New: valueOf(newV), Mode: mode})
+		}
+		return
+	}
+
+	switch {
+	case oldV.Kind() == reflect.Struct && hasExportedFields(oldV.Type()):
+		t := oldV.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			// yaml.v3 also fills the exported fields of inlined unexported embedded structs
+			embedded := field.Anonymous && field.Type.Kind() == reflect.Struct && isInline(field) && hasExportedFields(field.Type)
+			if !field.IsExported() && !embedded {
+				continue
+			}
+			fieldMode := mode
+			switch field.Tag.Get(ReloadTag) {
+			case "hot":
+				fieldMode = ReloadHot
+			case "restart":
+				fieldMode = ReloadRestart
+			}
+			fieldPath := path
+			if !isInline(field) {
+				fieldPath = joinPath(path, fieldName(field))
+			}
+			compareValues(d, fieldPath, oldV.Field(i), newV.Field(i), fieldMode)
+		}
+	case oldV.Kind() == reflect.Slice || oldV.Kind() == reflect.Array:
+		n := oldV.Len()
+		if newV.Len() > n {
+			n = newV.Len()
+		}
+		for i := 0; i < n; i++ {
+			var o, nv reflect.Value
+			if i < oldV.Len() {
+				o = oldV.Index(i)
+			}
+			if i < newV.Len() {
+				nv = newV.Index(i)
+			}
+			compareValues(d, fmt.Sprintf("%s[%d]", path, i), o, nv, mode)
+		}
+	case oldV.Kind() == reflect.Map:
+		keys := make(map[string]reflect.Value)
+		for _, k := range oldV.MapKeys() {
+			keys[fmt.Sprint(k.Interface())] = k
+		}
+		for _, k := range newV.MapKeys() {
+			keys[fmt.Sprint(k.Interface())] = k
+		}
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			k := keys[name]
+			compareValues(d, joinPath(path, name), oldV.MapIndex(k), newV.MapIndex(k), mode)
+		}
+	case oldV.Kind() == reflect.Func || oldV.Kind() == reflect.Chan:
+		// Never deeply equal unless nil; the same func or channel is unchanged
+		if oldV.Pointer() != newV.Pointer() {
+			d.Changes = append(d.Changes, Change{Path: rootPath(path), Old: oldV.Interface(), New: newV.Interface(), Mode: mode})
+		}
+	default:
+		if !reflect.DeepEqual(oldV.Interface(), newV.Interface()) {
+			d.Changes = append(d.Changes, Change{Path: rootPath(path), Old: oldV.Interface(), New: newV.Interface(), Mode: mode})
+		}
+	}
+}
+
+// hasExportedFields reports whether a struct type has exported fields.
+// Structs without them (e.g. time.Time) are compared as a single value.
+func hasExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldName returns the YAML name of a struct field, falling back to the Go name.
+func fieldName(field reflect.StructField) string {
+	if tag := field.Tag.Get("yaml"); tag != "" {
+		if name := strings.Split(tag, ",")[0]; name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// isInline reports whether a field's YAML tag inlines it into its parent.
+func isInline(field reflect.StructField) bool {
+	options := strings.Split(field.Tag.Get("yaml"), ",")
+	for _, option := range options[1:] {
+		if option == "inline" {
+			return true
+		}
+	}
+	return false
+}
+
+// joinPath appends a name to a dotted path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// rootPath names the root value when the whole config changed.
+func rootPath(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
+
+// valueOf returns the value's interface, or nil for an invalid value.
+func valueOf(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
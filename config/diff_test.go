@@ -0,0 +1,304 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type diffSource struct {
+	Name     string        `yaml:"name"`
+	Interval int           `yaml:"interval"`
+	Timeout  time.Duration `yaml:"timeout" reload:"hot"`
+}
+
+type diffFilters struct {
+	Keywords []string `yaml:"keywords"`
+	Limit    int      `yaml:"limit" reload:"restart"` // Overrides the hot parent
+}
+
+type diffCommon struct {
+	LogLevel string `yaml:"logLevel" reload:"hot"`
+}
+
+type diffOpaque struct {
+	n int
+}
+
+type diffConfig struct {
+	diffCommon `yaml:",inline"`
+	diffOpaque `yaml:",inline"` // No exported fields, ignored
+
+	Enabled bool                    `yaml:"enabled"`
+	Sources []diffSource            `yaml:"sources"`
+	Filters diffFilters             `yaml:"filters" reload:"hot"`
+	Headers map[string]string       `yaml:"headers" reload:"hot"`
+	Limits  map[string]*diffSource  `yaml:"limits"`
+	State   *diffSource             `yaml:"state"`
+	Extra   interface{}             `yaml:"extra"`
+	Since   time.Time               `yaml:"since"`
+	OnEvent func()                  `yaml:"-"`
+	Events  chan struct{}           `yaml:"-"`
+	Nested  map[string][]diffSource `yaml:"nested"`
+	cache   map[string]string       // Unexported, ignored
+}
+
+func baseDiffConfig() *diffConfig {
+	return &diffConfig{
+		diffCommon: diffCommon{LogLevel: "info"},
+		Enabled:    true,
+		Sources:    []diffSource{{Name: "a", Interval: 60}, {Name: "b", Interval: 120}},
+		Filters:    diffFilters{Keywords: []string{"x"}, Limit: 10},
+		Headers:    map[string]string{"k": "v"},
+		Limits:     map[string]*diffSource{"a": {Name: "a"}},
+		State:      &diffSource{Name: "s"},
+		Since:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		cache:      map[string]string{"a": "b"},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	onEvent := func() {}
+	events := make(chan struct{})
+
+	tests := []struct {
+		name    string
+		change  func(c *diffConfig)
+		base    func(c *diffConfig) // Optional change of the old config
+		paths   []string
+		restart bool
+	}{
+		{
+			name:  "equal",
+			paths: nil,
+		},
+		{
+			name:    "top-level scalar",
+			change:  func(c *diffConfig) { c.Enabled = false },
+			paths:   []string{"enabled"},
+			restart: true,
+		},
+		{
+			name:    "nested slice element field",
+			change:  func(c *diffConfig) { c.Sources[1].Interval = 300 },
+			paths:   []string{"sources[1].interval"},
+			restart: true,
+		},
+		{
+			name:   "hot field in slice element",
+			change: func(c *diffConfig) { c.Sources[0].Timeout = time.Second },
+			paths:  []string{"sources[0].timeout"},
+		},
+		{
+			name:    "slice grows",
+			change:  func(c *diffConfig) { c.Sources = append(c.Sources, diffSource{Name: "c"}) },
+			paths:   []string{"sources[2]"},
+			restart: true,
+		},
+		{
+			name:    "slice shrinks",
+			change:  func(c *diffConfig) { c.Sources = c.Sources[:1] },
+			paths:   []string{"sources[1]"},
+			restart: true,
+		},
+		{
+			name:    "nil and empty slices are equal",
+			base:    func(c *diffConfig) { c.Sources = nil },
+			change:  func(c *diffConfig) { c.Sources = []diffSource{} },
+			paths:   nil,
+			restart: false,
+		},
+		{
+			name:   "hot parent",
+			change: func(c *diffConfig) { c.Filters.Keywords = []string{"x", "y"} },
+			paths:  []string{"filters.keywords[1]"},
+		},
+		{
+			name:    "restart field under hot parent",
+			change:  func(c *diffConfig) { c.Filters.Limit = 20 },
+			paths:   []string{"filters.limit"},
+			restart: true,
+		},
+		{
+			name: "map key added, changed and removed",
+			change: func(c *diffConfig) {
+				c.Headers = map[string]string{"k": "w", "n": "1"}
+			},
+			paths: []string{"headers.k", "headers.n"},
+		},
+		{
+			name:   "map key removed",
+			change: func(c *diffConfig) { c.Headers = nil },
+			paths:  []string{"headers.k"},
+		},
+		{
+			name:    "map of pointers to struct",
+			change:  func(c *diffConfig) { c.Limits["a"].Interval = 5 },
+			paths:   []string{"limits.a.interval"},
+			restart: true,
+		},
+		{
+			name:    "map value becomes nil pointer",
+			change:  func(c *diffConfig) { c.Limits["a"] = nil },
+			paths:   []string{"limits.a"},
+			restart: true,
+		},
+		{
+			name:    "map of slices of structs",
+			base:    func(c *diffConfig) { c.Nested = map[string][]diffSource{"g": {{Name: "a"}}} },
+			change:  func(c *diffConfig) { c.Nested = map[string][]diffSource{"g": {{Name: "b"}}} },
+			paths:   []string{"nested.g[0].name"},
+			restart: true,
+		},
+		{
+			name:    "pointer to struct field",
+			change:  func(c *diffConfig) { c.State.Interval = 1 },
+			paths:   []string{"state.interval"},
+			restart: true,
+		},
+		{
+			name:    "pointer becomes nil",
+			change:  func(c *diffConfig) { c.State = nil },
+			paths:   []string{"state"},
+			restart: true,
+		},
+		{
+			name:    "nil pointer becomes set",
+			base:    func(c *diffConfig) { c.State = nil },
+			change:  func(c *diffConfig) { c.State = &diffSource{} },
+			paths:   []string{"state"},
+			restart: true,
+		},
+		{
+			name:    "interface changes dynamic type",
+			base:    func(c *diffConfig) { c.Extra = 1 },
+			change:  func(c *diffConfig) { c.Extra = "1" },
+			paths:   []string{"extra"},
+			restart: true,
+		},
+		{
+			name:    "struct without exported fields compared as a value",
+			change:  func(c *diffConfig) { c.Since = c.Since.Add(time.Hour) },
+			paths:   []string{"since"},
+			restart: true,
+		},
+		{
+			name:   "inline field keeps the parent path",
+			change: func(c *diffConfig) { c.LogLevel = "debug" },
+			paths:  []string{"logLevel"},
+		},
+		{
+			name:   "unexported fields are ignored",
+			change: func(c *diffConfig) { c.cache, c.n = map[string]string{"a": "c"}, 1 },
+			paths:  nil,
+		},
+		{
+			name:   "same func and channel",
+			base:   func(c *diffConfig) { c.OnEvent, c.Events = onEvent, events },
+			change: func(c *diffConfig) { c.OnEvent, c.Events = onEvent, events },
+			paths:  nil,
+		},
+		{
+			name:    "different channel",
+			base:    func(c *diffConfig) { c.Events = events },
+			change:  func(c *diffConfig) { c.Events = make(chan struct{}) },
+			paths:   []string{"Events"},
+			restart: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldCfg, newCfg := baseDiffConfig(), baseDiffConfig()
+			if tt.base != nil {
+				tt.base(oldCfg)
+				tt.base(newCfg)
+			}
+			if tt.change != nil {
+				tt.change(newCfg)
+			}
+
+			d, err := Compare(oldCfg, newCfg)
+			if err != nil {
+				t.Fatalf("Compare = %v", err)
+			}
+			if paths := d.Paths(); !reflect.DeepEqual(paths, tt.paths) && !(len(paths) == 0 && len(tt.paths) == 0) {
+				t.Errorf("paths = %v, want %v", paths, tt.paths)
+			}
+			if d.RequiresRestart() != tt.restart {
+				t.Errorf("RequiresRestart = %v, want %v (%v)", d.RequiresRestart(), tt.restart, d.Changes)
+			}
+			if d.Empty() != (len(tt.paths) == 0) {
+				t.Errorf("Empty = %v with changes %v", d.Empty(), d.Changes)
+			}
+		})
+	}
+}
+
+func TestCompareValues(t *testing.T) {
+	oldCfg, newCfg := baseDiffConfig(), baseDiffConfig()
+	newCfg.Sources[1].Interval = 300
+	newCfg.Headers["n"] = "1"
+
+	d, err := Compare(oldCfg, newCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Change{
+		{Path: "sources[1].interval", Old: 120, New: 300, Mode: ReloadRestart},
+		{Path: "headers.n", Old: nil, New: "1", Mode: ReloadHot},
+	}
+	if !reflect.DeepEqual(d.Changes, want) {
+		t.Errorf("Changes = %v, want %v", d.Changes, want)
+	}
+}
+
+func TestCompareRoot(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new interface{}
+		paths    []string
+		wantErr  bool
+	}{
+		{name: "both nil"},
+		{name: "nil to config", old: nil, new: &diffSource{}, paths: []string{"."}},
+		{name: "nil pointer to config", old: (*diffSource)(nil), new: &diffSource{}, paths: []string{"."}},
+		{name: "type mismatch", old: &diffSource{}, new: &diffFilters{}, wantErr: true},
+		{name: "values", old: diffSource{Name: "a"}, new: diffSource{Name: "b"}, paths: []string{"name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := Compare(tt.old, tt.new)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Compare error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if paths := d.Paths(); len(paths) != len(tt.paths) || (len(paths) > 0 && !reflect.DeepEqual(paths, tt.paths)) {
+				t.Errorf("paths = %v, want %v", paths, tt.paths)
+			}
+		})
+	}
+}
+
+func TestDiffChanged(t *testing.T) {
+	d := Diff{Changes: []Change{{Path: "sources[1].url"}, {Path: "filters.keywords"}}}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"sources", true},
+		{"sources[1]", true},
+		{"sources[1].url", true},
+		{"sources[0]", false},
+		{"filters", true},
+		{"filter", false},
+		{"filters.keywords", true},
+		{"filters.keywordsExtra", false},
+	}
+	for _, tt := range tests {
+		if got := d.Changed(tt.path); got != tt.want {
+			t.Errorf("Changed(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
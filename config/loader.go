@@ -41,6 +41,38 @@ func (l *Loader) LoadWithFallback(
 	return fmt.Errorf("no config found for plugin: %s", pluginName)
 }
 
+// LoadSectionWithFallback is LoadWithFallback for config files keyed by section,
+// e.g. a plugin's config.yaml with its defaults under "announcement:".
+// Priority: host project config > plugin default config
+//
+// Parameters:
+//   - pluginName: plugin name (used to build config file path)
+//   - section: top-level key holding the config
+//   - defaultConfigData: plugin embedded default config data (obtained via go:embed)
+//   - target: config struct pointer to store parsed config
+//
+// Returns:
+//   - error: returns error if loading fails or the section is missing
+func (l *Loader) LoadSectionWithFallback(
+	pluginName string,
+	section string,
+	defaultConfigData []byte,
+	target interface{},
+) error {
+	// 1. Try to load config from host project
+	hostConfigPath := filepath.Join("plugins", pluginName, "config.yaml")
+	if data, err := os.ReadFile(hostConfigPath); err == nil {
+		return UnmarshalYAMLSection(data, section, target)
+	}
+
+	// 2. Use plugin embedded default config
+	if defaultConfigData != nil {
+		return UnmarshalYAMLSection(defaultConfigData, section, target)
+	}
+
+	return fmt.Errorf("no config found for plugin: %s", pluginName)
+}
+
 // Load loads config file directly from specified path.
 //
 // Parameters:
@@ -126,6 +158,41 @@ func GetYAMLSection(raw []byte, section string) ([]byte, error) {
 	return yaml.Marshal(sectionData)
 }
 
+// GetYAMLSectionItems gets raw data of each element of a list section from raw YAML.
+// Used to build one plugin instance per element of a boot.yaml section.
+//
+// Parameters:
+//   - raw: raw YAML data
+//   - section: config section name
+//
+// Returns:
+//   - [][]byte: YAML data of each element, in order
+//   - error: returns error if parsing fails or the section is not a list
+func GetYAMLSectionItems(raw []byte, section string) ([][]byte, error) {
+	var rootMap map[string]yaml.Node
+	if err := yaml.Unmarshal(raw, &rootMap); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	sectionNode, ok := rootMap[section]
+	if !ok {
+		return nil, fmt.Errorf("section '%s' not found in config", section)
+	}
+	if sectionNode.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("section '%s' must be a list of instances", section)
+	}
+
+	items := make([][]byte, 0, len(sectionNode.Content))
+	for i, item := range sectionNode.Content {
+		data, err := yaml.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s[%d]: %w", section, i, err)
+		}
+		items = append(items, data)
+	}
+	return items, nil
+}
+
 // HasYAMLSection checks if a specified section exists in YAML.
 func HasYAMLSection(raw []byte, section string) bool {
 	var rootMap map[string]interface{}
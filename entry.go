@@ -2,6 +2,8 @@ package plugGo
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"time"
 )
 
@@ -29,11 +31,73 @@ type Entry interface {
 	String() string
 }
 
+// BootstrapTimeoutEntry is an optional interface for Entries with their own bootstrap timeout.
+// A positive value overrides the Boot's EntryBootstrapTimeout for that Entry.
+type BootstrapTimeoutEntry interface {
+	BootstrapTimeout() time.Duration
+}
+
+// BootstrapOrderEntry is an optional interface for Entries that must start before or after others.
+// Within each stage (plugin Entries, then user Entries) Entries bootstrap in ascending order;
+// with parallel bootstrap, Entries of the same order start concurrently. The default order is 0.
+type BootstrapOrderEntry interface {
+	BootstrapOrder() int
+}
+
+// BootstrapErrorEntry is an optional interface for Entries whose bootstrap can fail.
+// Boot calls BootstrapWithError instead of Bootstrap and reports a returned error as
+// OutcomeError in the BootstrapError.
+type BootstrapErrorEntry interface {
+	BootstrapWithError(ctx context.Context) error
+}
+
+// InterruptErrorEntry is an optional interface for Entries whose interrupt can fail.
+// Boot calls InterruptWithError instead of Interrupt and reports a returned error as
+// OutcomeError, e.g. in the ShutdownReport.
+type InterruptErrorEntry interface {
+	InterruptWithError(ctx context.Context) error
+}
+
+// ReloadConfigEntry is an optional interface for Entries that apply config changes in place.
+// Boot.ReloadConfig (SIGHUP by default) calls it with the re-read boot.yaml content.
+type ReloadConfigEntry interface {
+	ReloadConfig(ctx context.Context, raw []byte) error
+}
+
+// RemovableEntry is an optional interface for Entries holding resources outside Boot,
+// e.g. a registry instance. Boot.RemoveEntry calls Remove after interrupting the Entry.
+type RemovableEntry interface {
+	Remove() error
+}
+
 // RegFunc is the registration function type for Entry.
 // Creates Entry instances from raw YAML config.
 // Returns map[name]Entry, supporting multiple instances of the same type.
 type RegFunc func(raw []byte) map[string]Entry
 
+// RegFuncWithError is a RegFunc that also reports the Entries it could not create,
+// e.g. an instance whose config fails to decode or validate. Boot.Bootstrap reports
+// each of these as a failed Entry in its BootstrapError, instead of skipping it silently.
+// Return an EntryBuildError (or several, joined with errors.Join) to name the Entry.
+type RegFuncWithError func(raw []byte) (map[string]Entry, error)
+
+// EntryBuildError is the error of a RegFuncWithError for one Entry it could not create.
+type EntryBuildError struct {
+	Type string // Entry type, e.g. the plugin type
+	Name string // Entry name, e.g. the instance name
+	Err  error
+}
+
+// Error formats the error as "type name: cause".
+func (e *EntryBuildError) Error() string {
+	return fmt.Sprintf("%s %s: %v", e.Type, e.Name, e.Err)
+}
+
+// Unwrap returns the cause.
+func (e *EntryBuildError) Unwrap() error {
+	return e.Err
+}
+
 // EntryType defines Entry type constants.
 const (
 	// PluginEntryType is the plugin type.
@@ -45,6 +109,9 @@ const (
 // ShutdownHook is the shutdown hook function type.
 type ShutdownHook func()
 
+// ShutdownHookFunc is a shutdown hook receiving the shutdown context (see AddShutdownHookWithContext).
+type ShutdownHookFunc func(ctx context.Context) error
+
 // BootConfig holds bootstrap configuration options.
 type BootConfig struct {
 	// ConfigPath is the config file path, defaults to boot.yaml.
@@ -55,6 +122,25 @@ type BootConfig struct {
 	ShutdownTimeout time.Duration
 	// EntryShutdownTimeout is the timeout for shutting down a single Entry, defaults to 10s.
 	EntryShutdownTimeout time.Duration
+	// ShutdownHookTimeout is the timeout for a single shutdown hook, defaults to 10s.
+	ShutdownHookTimeout time.Duration
+	// DrainPeriod is how long shutdown waits after reporting not ready, before draining Entries (none by default).
+	DrainPeriod time.Duration
+	// DrainTimeout is the timeout for draining a single Entry, defaults to 10s.
+	DrainTimeout time.Duration
+	// StartupTimeout is the overall bootstrap timeout, no limit by default.
+	StartupTimeout time.Duration
+	// EntryBootstrapTimeout is the timeout for bootstrapping a single Entry, defaults to 60s.
+	EntryBootstrapTimeout time.Duration
+	// StartupSignals cancel a bootstrap in progress, defaults to SIGINT and SIGTERM.
+	// An empty non-nil slice disables signal handling during bootstrap.
+	StartupSignals []os.Signal
+	// BootstrapWorkers is the number of Entries bootstrapped concurrently, 1 (sequential) by default.
+	BootstrapWorkers int
+	// ShutdownSignals start a graceful shutdown, defaults to SIGINT and SIGTERM.
+	ShutdownSignals []os.Signal
+	// SignalActions override the default action signals (SIGHUP, SIGUSR1, SIGUSR2); nil actions disable them.
+	SignalActions map[os.Signal]SignalAction
 }
 
 // BootOption is a bootstrap configuration option function.
@@ -74,7 +160,8 @@ func WithConfigRaw(raw []byte) BootOption {
 	}
 }
 
-// WithShutdownTimeout sets the overall shutdown timeout.
+// WithShutdownTimeout sets the overall timeout of the shutdown hooks and Entry interrupts.
+// It starts once the drain ends: the drain period and drain timeout come on top of it.
 func WithShutdownTimeout(timeout time.Duration) BootOption {
 	return func(c *BootConfig) {
 		c.ShutdownTimeout = timeout
@@ -87,3 +174,62 @@ func WithEntryShutdownTimeout(timeout time.Duration) BootOption {
 		c.EntryShutdownTimeout = timeout
 	}
 }
+
+// WithDefaultShutdownHookTimeout sets the timeout for shutdown hooks without their own
+// (see WithShutdownHookTimeout).
+func WithDefaultShutdownHookTimeout(timeout time.Duration) BootOption {
+	return func(c *BootConfig) {
+		c.ShutdownHookTimeout = timeout
+	}
+}
+
+// WithDrainPeriod makes shutdown wait after Boot reports not ready (see Boot.Ready),
+// so load balancers stop sending traffic before Entries are drained and interrupted.
+// The wait isn't counted in the ShutdownTimeout.
+func WithDrainPeriod(period time.Duration) BootOption {
+	return func(c *BootConfig) {
+		c.DrainPeriod = period
+	}
+}
+
+// WithDrainTimeout sets the timeout for draining a single Entry (see Drainer).
+// Entries drain concurrently, so the drain takes at most the drain period plus this
+// timeout, before the ShutdownTimeout starts.
+func WithDrainTimeout(timeout time.Duration) BootOption {
+	return func(c *BootConfig) {
+		c.DrainTimeout = timeout
+	}
+}
+
+// WithStartupTimeout sets the overall bootstrap timeout.
+func WithStartupTimeout(timeout time.Duration) BootOption {
+	return func(c *BootConfig) {
+		c.StartupTimeout = timeout
+	}
+}
+
+// WithEntryBootstrapTimeout sets the timeout for bootstrapping a single Entry.
+// Entries implementing BootstrapTimeoutEntry (e.g. plugin instances with
+// `bootstrapTimeout` in boot.yaml) can override it.
+func WithEntryBootstrapTimeout(timeout time.Duration) BootOption {
+	return func(c *BootConfig) {
+		c.EntryBootstrapTimeout = timeout
+	}
+}
+
+// WithStartupSignals sets the signals that cancel a bootstrap in progress.
+// Call it without arguments to leave signals alone during bootstrap.
+func WithStartupSignals(signals ...os.Signal) BootOption {
+	return func(c *BootConfig) {
+		c.StartupSignals = append([]os.Signal{}, signals...)
+	}
+}
+
+// WithParallelBootstrap bootstraps up to workers Entries concurrently.
+// Ordering constraints still hold: plugin Entries start before user Entries and
+// lower BootstrapOrder groups before higher ones. Hooks run around each Entry.
+func WithParallelBootstrap(workers int) BootOption {
+	return func(c *BootConfig) {
+		c.BootstrapWorkers = workers
+	}
+}
@@ -1,21 +1,30 @@
 package config
 
+import "time"
+
 // Config is the config structure for announcement monitor plugin.
 // Supports the new boot.yaml unified config format.
+// Fields tagged reload:"hot" are applied to a running monitor without restarting source pollers.
 type Config struct {
 	// Basic config (directly under entry node)
-	Name     string `yaml:"name"`     // Instance name
-	Enabled  bool   `yaml:"enabled"`  // Whether enabled
-	LogLevel string `yaml:"logLevel"` // Log level
+	// Whether the instance runs is plugGo.InstanceMeta's "enabled", owned by its Entry
+	Name     string `yaml:"name" reload:"hot"`     // Instance name
+	LogLevel string `yaml:"logLevel" reload:"hot"` // Log level
 
 	// Announcement sources config
 	Sources []Source `yaml:"sources"`
 
 	// Notification config
-	Notifications []Notification `yaml:"notifications"`
+	Notifications []Notification `yaml:"notifications" reload:"hot"`
+
+	// JSON-lines file receiving notifications that could not be delivered (only logged if empty)
+	DeadLetter string `yaml:"deadLetter" reload:"hot"`
 
 	// Filters config
-	Filters Filters `yaml:"filters"`
+	Filters Filters `yaml:"filters" reload:"hot"`
+
+	// Seen-item state config (de-duplication across restarts and reloads)
+	State State `yaml:"state"`
 }
 
 // Source is the announcement source config.
@@ -23,15 +32,152 @@ type Source struct {
 	Name     string `yaml:"name"`     // Source name
 	URL      string `yaml:"url"`      // Source URL
 	Interval int    `yaml:"interval"` // Polling interval (seconds)
+	Schedule string `yaml:"schedule"` // Cron schedule instead of interval, e.g. "*/10 * * * *" or "CRON_TZ=UTC 0 9 * * MON-FRI"
+
+	// Polling controls; the first poll happens right after start
+	Timeout    time.Duration `yaml:"timeout"`    // HTTP timeout of one fetch (default 30s)
+	Jitter     time.Duration `yaml:"jitter"`     // Random delay up to this added to every interval
+	MaxBackoff time.Duration `yaml:"maxBackoff"` // Ceiling of the interval doubling after failures (default 1h)
+	RateLimit  time.Duration `yaml:"rateLimit"`  // Minimum time between requests to the source host, shared by all instances
+
+	// Filters applied to this source in addition to the instance filters
+	Filters *Filters `yaml:"filters" reload:"hot"`
+
+	// Format of the feed: auto (default), rss, atom, jsonfeed or json (requires mapping)
+	Format string `yaml:"format"`
+	// Mapping extracts announcements from arbitrary JSON APIs (format: json)
+	Mapping *JSONMapping `yaml:"mapping"`
+}
+
+// Feed formats supported by Source.Format.
+const (
+	FormatAuto     = "auto"
+	FormatRSS      = "rss"
+	FormatAtom     = "atom"
+	FormatJSONFeed = "jsonfeed"
+	FormatJSON     = "json"
+)
+
+// JSONMapping maps fields of an arbitrary JSON API response to announcements.
+// Paths are dotted with optional indices, e.g. "data.items" or "$.result[0].list".
+// Item paths are relative to each element of Items.
+type JSONMapping struct {
+	Items      string `yaml:"items"`      // Path to the array of items ("" or "$" for a top-level array)
+	ID         string `yaml:"id"`         // Item ID path
+	Title      string `yaml:"title"`      // Item title path
+	Link       string `yaml:"link"`       // Item link path
+	Published  string `yaml:"published"`  // Item publish time path
+	Body       string `yaml:"body"`       // Item body path
+	TimeFormat string `yaml:"timeFormat"` // Go time layout of Published (default: RFC3339 or unix seconds)
 }
 
 // Notification is the notification config.
+// Template and Subject are Go text/template strings executed with the notification message
+// (instance, source, id, title, link, published, body); an empty Template uses a default format.
 type Notification struct {
-	Type string `yaml:"type"` // Notification type (webhook, email, etc.)
-	URL  string `yaml:"url"`  // Webhook URL or other address
+	Type string `yaml:"type"` // Notification type: webhook, email, file, stdout or channel
+	Name string `yaml:"name"` // Name used in logs and dead letters (default: type)
+	URL  string `yaml:"url"`  // Webhook URL
+
+	Method  string            `yaml:"method"`  // Webhook HTTP method (default POST)
+	Headers map[string]string `yaml:"headers"` // Extra webhook HTTP headers
+	Path    string            `yaml:"path"`    // Output file (file type)
+	SMTP    *SMTP             `yaml:"smtp"`    // SMTP server and addresses (email type)
+
+	Template string `yaml:"template"` // Message template (webhook: JSON body)
+	Subject  string `yaml:"subject"`  // Email subject template
+
+	Timeout     time.Duration `yaml:"timeout"`     // Timeout of one delivery attempt (default 10s)
+	Concurrency int           `yaml:"concurrency"` // Deliveries in flight at once (default 1)
+	QueueSize   int           `yaml:"queueSize"`   // Pending deliveries before new ones are dead-lettered (default 100)
+	Retry       Retry         `yaml:"retry"`       // Retry policy
+}
+
+// Notification types supported by Notification.Type.
+const (
+	NotifyWebhook = "webhook"
+	NotifyEmail   = "email"
+	NotifyFile    = "file"
+	NotifyStdout  = "stdout"
+	NotifyChannel = "channel" // Plugin notify channel (GetNotifyChannel)
+)
+
+// SMTP is the SMTP config of email notifications.
+// STARTTLS is used when the server offers it; authentication requires it unless the host is local.
+type SMTP struct {
+	Host     string   `yaml:"host"`     // Server host
+	Port     int      `yaml:"port"`     // Server port (default 25)
+	Username string   `yaml:"username"` // Auth username (no auth if empty)
+	Password string   `yaml:"password"` // Auth password
+	From     string   `yaml:"from"`     // Sender address
+	To       []string `yaml:"to"`       // Recipient addresses
+}
+
+// Retry is the retry policy of a notification.
+// Backoff doubles after every failed attempt, starting at InitialBackoff, up to MaxBackoff.
+type Retry struct {
+	MaxAttempts    int           `yaml:"maxAttempts"`    // Attempts including the first one (default 3)
+	InitialBackoff time.Duration `yaml:"initialBackoff"` // Wait before the first retry (default 1s)
+	MaxBackoff     time.Duration `yaml:"maxBackoff"`     // Upper bound of the wait (default 1m)
 }
 
 // Filters is the filters config.
+// An announcement passes if it contains one of the include keywords (when any are set),
+// none of the exclude keywords, and satisfies Match (when set).
+//
+//	filters:
+//	  include: ["maintenance", "upgrade"]
+//	  exclude: ["test"]
+//	  match:
+//	    any:
+//	      - regex: "v\\d+\\.\\d+"
+//	        fields: ["title"]
+//	      - not: {contains: ["minor"]}
 type Filters struct {
-	Keywords []string `yaml:"keywords"` // Keywords list
+	Keywords      []string `yaml:"keywords"`      // Include keywords (same as Include)
+	Include       []string `yaml:"include"`       // Pass only items containing one of these
+	Exclude       []string `yaml:"exclude"`       // Drop items containing any of these
+	Fields        []string `yaml:"fields"`        // Fields matched: title, body, link (default title and body)
+	CaseSensitive bool     `yaml:"caseSensitive"` // Case-sensitive matching (default false)
+	Match         *Rule    `yaml:"match"`         // Boolean expression items must satisfy
+}
+
+// Empty reports whether the filters pass everything.
+func (f Filters) Empty() bool {
+	return len(f.Keywords) == 0 && len(f.Include) == 0 && len(f.Exclude) == 0 && f.Match == nil
+}
+
+// Rule is a boolean filter expression.
+// Exactly one of All, Any, Not, Contains or Regex must be set.
+type Rule struct {
+	All []Rule `yaml:"all"` // Matches if every rule matches
+	Any []Rule `yaml:"any"` // Matches if at least one rule matches
+	Not *Rule  `yaml:"not"` // Matches if the rule doesn't match
+
+	Contains      []string `yaml:"contains"`      // Matches if a field contains one of these keywords
+	Regex         string   `yaml:"regex"`         // Matches if a field matches this regular expression
+	Fields        []string `yaml:"fields"`        // Fields of Contains / Regex (default: Filters.Fields)
+	CaseSensitive *bool    `yaml:"caseSensitive"` // Overrides Filters.CaseSensitive
+}
+
+// Announcement fields filters can match.
+const (
+	FieldTitle = "title"
+	FieldBody  = "body"
+	FieldLink  = "link"
+)
+
+// State is the seen-item store config.
+// Seen IDs are keyed by instance name and source name, so several instances may share one path.
+type State struct {
+	Backend  string        `yaml:"backend"`  // memory (default), file or kv
+	Path     string        `yaml:"path"`     // Store file path (file and kv backends)
+	MaxItems int           `yaml:"maxItems"` // Seen IDs kept per source, should exceed the feed size (default 1000)
+	MaxAge   time.Duration `yaml:"maxAge"`   // Forget IDs older than this, e.g. "720h" (0 keeps them)
+	Baseline *bool         `yaml:"baseline"` // Record a source's current items without notifying on first run (default true)
+}
+
+// BaselineEnabled reports whether first-run baselining is enabled (enabled unless set to false).
+func (s State) BaselineEnabled() bool {
+	return s.Baseline == nil || *s.Baseline
 }
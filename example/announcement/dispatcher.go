@@ -0,0 +1,293 @@
+package announcement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/seencxy/plugGo"
+	"github.com/seencxy/plugGo/example/announcement/config"
+)
+
+// Delivery defaults applied to unset notification settings.
+const (
+	defaultNotifyTimeout     = 10 * time.Second
+	defaultNotifyConcurrency = 1
+	defaultNotifyQueueSize   = 100
+	defaultMaxAttempts       = 3
+	defaultInitialBackoff    = time.Second
+	defaultMaxBackoff        = time.Minute
+
+	// dispatcherDrainTimeout bounds delivery of a dispatcher replaced by a reload
+	dispatcherDrainTimeout = 30 * time.Second
+)
+
+// DeadLetter is a notification that could not be delivered.
+type DeadLetter struct {
+	Time     time.Time `json:"time"`
+	Notifier string    `json:"notifier"`
+	Attempts int       `json:"attempts"`
+	Error    string    `json:"error"`
+	Message  Message   `json:"message"`
+}
+
+// Dispatcher delivers messages to a set of notifiers.
+// Each notifier has its own bounded queue and workers, so a slow destination
+// neither blocks polling nor delays the others. Messages that exhaust their
+// retries, or don't fit in the queue, are written to the dead-letter log.
+type Dispatcher struct {
+	routes     []*route
+	logger     plugGo.Logger
+	deadLetter string // JSON-lines file path, empty to only log
+
+	ctx    context.Context    // Cancelled to abort in-flight deliveries
+	cancel context.CancelFunc // Cancels ctx
+	wg     sync.WaitGroup     // Workers
+	closed bool
+	mu     sync.RWMutex // Protects closed and the queues against Close
+	dlMu   sync.Mutex   // Serializes dead-letter writes
+
+	pending int           // Queued or in-flight messages (guarded by pendMu)
+	idle    chan struct{} // Closed when pending drops to 0 (guarded by pendMu)
+	pendMu  sync.Mutex
+}
+
+// route is one notifier with its delivery settings and queue.
+type route struct {
+	notifier Notifier
+	timeout  time.Duration
+	retry    config.Retry
+	workers  int // Concurrency limit
+	queue    chan Message
+}
+
+// NewDispatcher creates a dispatcher for the configured notifications and starts its workers.
+// notifyCh is the plugin notify channel used by channel notifications.
+func NewDispatcher(cfg *config.Config, logger plugGo.Logger, notifyCh chan any) (*Dispatcher, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &Dispatcher{
+		logger:     logger,
+		deadLetter: cfg.DeadLetter,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+
+	for _, n := range cfg.Notifications {
+		notifier, err := NewNotifier(n, notifyCh)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		d.routes = append(d.routes, newRoute(notifier, n))
+	}
+
+	for _, r := range d.routes {
+		for i := 0; i < r.workers; i++ {
+			d.wg.Add(1)
+			go d.worker(r)
+		}
+	}
+	return d, nil
+}
+
+// newRoute applies delivery defaults to a notification config.
+func newRoute(notifier Notifier, cfg config.Notification) *route {
+	r := &route{
+		notifier: notifier,
+		timeout:  cfg.Timeout,
+		retry:    cfg.Retry,
+		workers:  cfg.Concurrency,
+	}
+	if r.workers == 0 {
+		r.workers = defaultNotifyConcurrency
+	}
+	if r.timeout == 0 {
+		r.timeout = defaultNotifyTimeout
+	}
+	if r.retry.MaxAttempts == 0 {
+		r.retry.MaxAttempts = defaultMaxAttempts
+	}
+	if r.retry.InitialBackoff == 0 {
+		r.retry.InitialBackoff = defaultInitialBackoff
+	}
+	if r.retry.MaxBackoff == 0 {
+		r.retry.MaxBackoff = defaultMaxBackoff
+	}
+	queueSize := cfg.QueueSize
+	if queueSize == 0 {
+		queueSize = defaultNotifyQueueSize
+	}
+	r.queue = make(chan Message, queueSize)
+	return r
+}
+
+// Dispatch queues a message for every notifier without blocking.
+// If a notifier's queue is full, or the dispatcher is closed, the message is dead-lettered for it.
+func (d *Dispatcher) Dispatch(msg Message) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for _, r := range d.routes {
+		if d.closed {
+			d.writeDeadLetter(r.notifier.Name(), 0, errors.New("dispatcher closed"), msg)
+			continue
+		}
+		d.addPending(1)
+		select {
+		case r.queue <- msg:
+		default:
+			d.addPending(-1)
+			d.writeDeadLetter(r.notifier.Name(), 0, errors.New("queue full"), msg)
+		}
+	}
+}
+
+// addPending counts messages entering (delta 1) or leaving (delta -1) the queues.
+func (d *Dispatcher) addPending(delta int) {
+	d.pendMu.Lock()
+	defer d.pendMu.Unlock()
+
+	if d.pending == 0 && delta > 0 {
+		d.idle = make(chan struct{})
+	}
+	d.pending += delta
+	if d.pending == 0 && d.idle != nil {
+		close(d.idle)
+		d.idle = nil
+	}
+}
+
+// Flush waits until every queued message is delivered or dead-lettered,
+// while the dispatcher keeps accepting messages.
+func (d *Dispatcher) Flush(ctx context.Context) error {
+	d.pendMu.Lock()
+	idle, pending := d.idle, d.pending
+	d.pendMu.Unlock()
+	if pending == 0 {
+		return nil
+	}
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("notifications not flushed: %w", ctx.Err())
+	}
+}
+
+// Close stops accepting messages and waits for queued ones to be delivered.
+// When ctx expires first, in-flight deliveries are aborted and the rest is dead-lettered.
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return nil
+	}
+	d.closed = true
+	for _, r := range d.routes {
+		close(r.queue)
+	}
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		d.cancel()
+		return nil
+	case <-ctx.Done():
+		d.cancel()
+		<-done
+		return fmt.Errorf("notifications not drained: %w", ctx.Err())
+	}
+}
+
+// worker delivers messages from a route's queue.
+func (d *Dispatcher) worker(r *route) {
+	defer d.wg.Done()
+	for msg := range r.queue {
+		d.deliver(r, msg)
+		d.addPending(-1)
+	}
+}
+
+// deliver tries a message until it succeeds, fails permanently or runs out of attempts.
+func (d *Dispatcher) deliver(r *route, msg Message) {
+	var err error
+	attempt := 0
+	for attempt < r.retry.MaxAttempts {
+		attempt++
+		if d.ctx.Err() != nil {
+			err = d.ctx.Err()
+			break
+		}
+
+		ctx, cancel := context.WithTimeout(d.ctx, r.timeout)
+		err = r.notifier.Notify(ctx, msg)
+		cancel()
+		if err == nil {
+			d.logger.Debug(fmt.Sprintf("Notification %s delivered: %s", r.notifier.Name(), msg.ID))
+			return
+		}
+		if IsPermanent(err) || attempt == r.retry.MaxAttempts {
+			break
+		}
+
+		wait := backoff(r.retry, attempt)
+		d.logger.Warn(fmt.Sprintf("Notification %s failed (attempt %d/%d), retrying in %v: %v",
+			r.notifier.Name(), attempt, r.retry.MaxAttempts, wait, err))
+		select {
+		case <-time.After(wait):
+		case <-d.ctx.Done():
+		}
+	}
+
+	d.writeDeadLetter(r.notifier.Name(), attempt, err, msg)
+}
+
+// writeDeadLetter logs an undeliverable message and appends it to the dead-letter file.
+func (d *Dispatcher) writeDeadLetter(notifier string, attempts int, cause error, msg Message) {
+	d.logger.Error(fmt.Sprintf("Notification %s dropped after %d attempts: %v (announcement %s)",
+		notifier, attempts, cause, msg.ID))
+	if d.deadLetter == "" {
+		return
+	}
+
+	line, err := json.Marshal(DeadLetter{
+		Time:     time.Now(),
+		Notifier: notifier,
+		Attempts: attempts,
+		Error:    cause.Error(),
+		Message:  msg,
+	})
+	if err != nil {
+		d.logger.Error("Failed to encode dead letter:", err)
+		return
+	}
+
+	d.dlMu.Lock()
+	defer d.dlMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(d.deadLetter), 0o755); err != nil {
+		d.logger.Error("Failed to write dead letter:", err)
+		return
+	}
+	f, err := os.OpenFile(d.deadLetter, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		d.logger.Error("Failed to write dead letter:", err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		d.logger.Error("Failed to write dead letter:", err)
+	}
+}
@@ -7,7 +7,8 @@ import (
 	"github.com/seencxy/plugGo"
 	plugGoConfig "github.com/seencxy/plugGo/config"
 	"github.com/seencxy/plugGo/example/announcement/config"
-	"github.com/seencxy/plugGo/registry"
+	"github.com/seencxy/plugGo/example/announcement/store"
+	"github.com/seencxy/plugGo/schedule"
 )
 
 //go:embed config.yaml
@@ -35,9 +36,9 @@ func (f *Factory) Version() string {
 func (f *Factory) DefaultConfig() interface{} {
 	cfg := &config.Config{}
 
-	// Load default config from embedded config file
+	// Load default config from the plugin's section of the embedded config file
 	loader := &plugGoConfig.Loader{}
-	if err := loader.LoadWithFallback(f.Name(), defaultConfigData, cfg); err != nil {
+	if err := loader.LoadSectionWithFallback(f.Name(), f.Name(), defaultConfigData, cfg); err != nil {
 		// If loading fails, return empty config
 		return &config.Config{}
 	}
@@ -65,11 +66,60 @@ func (f *Factory) ValidateConfig(cfg interface{}) error {
 		if source.URL == "" {
 			return fmt.Errorf("source[%d]: url is required", i)
 		}
-		if source.Interval <= 0 {
-			return fmt.Errorf("source[%d]: interval must be positive", i)
+		switch {
+		case source.Schedule != "" && source.Interval != 0:
+			return fmt.Errorf("source[%d]: interval and schedule are mutually exclusive", i)
+		case source.Schedule != "":
+			if _, err := schedule.Parse(source.Schedule); err != nil {
+				return fmt.Errorf("source[%d]: %w", i, err)
+			}
+		case source.Interval <= 0:
+			return fmt.Errorf("source[%d]: interval must be positive (or set a schedule)", i)
+		}
+		if source.Timeout < 0 || source.Jitter < 0 || source.MaxBackoff < 0 || source.RateLimit < 0 {
+			return fmt.Errorf("source[%d]: timeout, jitter, maxBackoff and rateLimit must not be negative", i)
+		}
+		switch source.Format {
+		case "", config.FormatAuto, config.FormatRSS, config.FormatAtom, config.FormatJSONFeed:
+		case config.FormatJSON:
+			if source.Mapping == nil || source.Mapping.Title == "" {
+				return fmt.Errorf("source[%d]: format json requires mapping with at least a title path", i)
+			}
+		default:
+			return fmt.Errorf("source[%d]: unsupported format %q", i, source.Format)
+		}
+	}
+
+	// Validate filters
+	if _, err := compileFilters(announcementCfg); err != nil {
+		return err
+	}
+
+	// Validate notifications
+	for i, n := range announcementCfg.Notifications {
+		if err := validateNotification(n); err != nil {
+			return fmt.Errorf("notifications[%d]: %w", i, err)
 		}
 	}
 
+	// Validate seen-item state config
+	state := announcementCfg.State
+	switch state.Backend {
+	case "", store.BackendMemory:
+	case store.BackendFile, store.BackendKV:
+		if state.Path == "" {
+			return fmt.Errorf("state: backend %s requires a path", state.Backend)
+		}
+	default:
+		return fmt.Errorf("state: unsupported backend %q", state.Backend)
+	}
+	if state.MaxItems < 0 {
+		return fmt.Errorf("state: maxItems must not be negative")
+	}
+	if state.MaxAge < 0 {
+		return fmt.Errorf("state: maxAge must not be negative")
+	}
+
 	return nil
 }
 
@@ -81,7 +131,7 @@ func (f *Factory) Create(instanceID string, cfg interface{}, logger plugGo.Logge
 	}
 
 	if logger == nil {
-		logger = plugGo.NewStandardLogger(fmt.Sprintf("%s-%s", PluginName, instanceID), plugGo.ParseLogLevel(announcementCfg.LogLevel))
+		logger = plugGo.NewStandardLogger(fmt.Sprintf(LoggerPrefix, instanceID), plugGo.ParseLogLevel(announcementCfg.LogLevel))
 	}
 
 	// Create plugin instance
@@ -90,15 +140,11 @@ func (f *Factory) Create(instanceID string, cfg interface{}, logger plugGo.Logge
 		pluginType: PluginName,
 		cfg:        announcementCfg,
 		logger:     logger,
-		status:     plugGo.StatusIdle,
-		statusCh:   make(chan plugGo.StatusEvent, 10), // buffered channel to avoid blocking
-		notifyCh:   make(chan any, 100),               // buffered channel for external notifications
+		statusCh:   make(chan plugGo.StatusEvent, 1),
+		notifyCh:   make(chan any, 100), // buffered channel for external notifications
+		fetcher:    NewFetcher(nil),
+		health:     newHealthTracker(),
 	}
 
 	return plugin, nil
 }
-
-// Auto-register plugin factory in init.
-func init() {
-	registry.RegisterFactory(NewFactory())
-}
@@ -0,0 +1,371 @@
+package announcement
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seencxy/plugGo/example/announcement/config"
+)
+
+// Announcement is a normalised feed item.
+type Announcement struct {
+	ID        string    `json:"id"`        // Stable identifier (guid, id or derived from link/title)
+	Source    string    `json:"source"`    // Name of the source it was fetched from
+	Title     string    `json:"title"`     // Item title
+	Link      string    `json:"link"`      // Item URL
+	Published time.Time `json:"published"` // Publish time (zero if unknown)
+	Body      string    `json:"body"`      // Item content or summary
+}
+
+// ParseFeed parses a feed body into announcements.
+// With FormatAuto (or empty) the format is detected from the content.
+func ParseFeed(data []byte, format string, mapping *config.JSONMapping) ([]Announcement, error) {
+	if format == "" || format == config.FormatAuto {
+		format = detectFormat(data, mapping)
+	}
+
+	var (
+		items []Announcement
+		err   error
+	)
+	switch format {
+	case config.FormatRSS:
+		items, err = parseRSS(data)
+	case config.FormatAtom:
+		items, err = parseAtom(data)
+	case config.FormatJSONFeed:
+		items, err = parseJSONFeed(data)
+	case config.FormatJSON:
+		if mapping == nil {
+			return nil, fmt.Errorf("format json requires a mapping")
+		}
+		items, err = parseJSONMapping(data, mapping)
+	default:
+		return nil, fmt.Errorf("unsupported feed format: %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s feed: %w", format, err)
+	}
+
+	for i := range items {
+		if items[i].ID == "" {
+			items[i].ID = derivedID(items[i])
+		}
+	}
+	return items, nil
+}
+
+// detectFormat guesses the feed format from the content.
+func detectFormat(data []byte, mapping *config.JSONMapping) string {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return ""
+	}
+
+	if trimmed[0] == '<' {
+		decoder := xml.NewDecoder(bytes.NewReader(trimmed))
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				return ""
+			}
+			if start, ok := token.(xml.StartElement); ok {
+				switch strings.ToLower(start.Name.Local) {
+				case "rss", "rdf":
+					return config.FormatRSS
+				case "feed":
+					return config.FormatAtom
+				}
+				return ""
+			}
+		}
+	}
+
+	if mapping != nil {
+		return config.FormatJSON
+	}
+	var probe struct {
+		Version string `json:"version"`
+	}
+	if json.Unmarshal(trimmed, &probe) == nil && strings.Contains(probe.Version, "jsonfeed.org") {
+		return config.FormatJSONFeed
+	}
+	return ""
+}
+
+// derivedID builds a stable ID for items without one.
+func derivedID(a Announcement) string {
+	if a.Link != "" {
+		return a.Link
+	}
+	sum := sha1.Sum([]byte(a.Title + "\n" + a.Body))
+	return hex.EncodeToString(sum[:])
+}
+
+// ===== RSS 2.0 =====
+
+type rssDocument struct {
+	Channel struct {
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+	Items []rssItem `xml:"item"` // RSS 1.0 (RDF) puts items at the root
+}
+
+type rssItem struct {
+	GUID        string `xml:"guid"`
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	PubDate     string `xml:"pubDate"`
+	Date        string `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Description string `xml:"description"`
+	Content     string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+}
+
+func parseRSS(data []byte) ([]Announcement, error) {
+	var doc rssDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	items := append(doc.Channel.Items, doc.Items...)
+	result := make([]Announcement, 0, len(items))
+	for _, item := range items {
+		body := item.Content
+		if body == "" {
+			body = item.Description
+		}
+		published := item.PubDate
+		if published == "" {
+			published = item.Date
+		}
+		result = append(result, Announcement{
+			ID:        strings.TrimSpace(item.GUID),
+			Title:     strings.TrimSpace(item.Title),
+			Link:      strings.TrimSpace(item.Link),
+			Published: parseTime(published, ""),
+			Body:      strings.TrimSpace(body),
+		})
+	}
+	return result, nil
+}
+
+// ===== Atom =====
+
+type atomFeed struct {
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Links     []atomLink `xml:"link"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+	Summary   string     `xml:"summary"`
+	Content   string     `xml:"content"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+func parseAtom(data []byte) ([]Announcement, error) {
+	var feed atomFeed
+	if err := xml.Unmarshal(data, &feed); err != nil {
+		return nil, err
+	}
+
+	result := make([]Announcement, 0, len(feed.Entries))
+	for _, entry := range feed.Entries {
+		var link string
+		for _, l := range entry.Links {
+			if l.Rel == "" || l.Rel == "alternate" {
+				link = l.Href
+				break
+			}
+		}
+		body := entry.Content
+		if body == "" {
+			body = entry.Summary
+		}
+		published := entry.Published
+		if published == "" {
+			published = entry.Updated
+		}
+		result = append(result, Announcement{
+			ID:        strings.TrimSpace(entry.ID),
+			Title:     strings.TrimSpace(entry.Title),
+			Link:      strings.TrimSpace(link),
+			Published: parseTime(published, ""),
+			Body:      strings.TrimSpace(body),
+		})
+	}
+	return result, nil
+}
+
+// ===== JSON Feed =====
+
+type jsonFeed struct {
+	Items []struct {
+		ID            string `json:"id"`
+		URL           string `json:"url"`
+		Title         string `json:"title"`
+		ContentHTML   string `json:"content_html"`
+		ContentText   string `json:"content_text"`
+		Summary       string `json:"summary"`
+		DatePublished string `json:"date_published"`
+	} `json:"items"`
+}
+
+func parseJSONFeed(data []byte) ([]Announcement, error) {
+	var feed jsonFeed
+	if err := json.Unmarshal(data, &feed); err != nil {
+		return nil, err
+	}
+
+	result := make([]Announcement, 0, len(feed.Items))
+	for _, item := range feed.Items {
+		body := item.ContentText
+		if body == "" {
+			body = item.ContentHTML
+		}
+		if body == "" {
+			body = item.Summary
+		}
+		result = append(result, Announcement{
+			ID:        item.ID,
+			Title:     item.Title,
+			Link:      item.URL,
+			Published: parseTime(item.DatePublished, ""),
+			Body:      body,
+		})
+	}
+	return result, nil
+}
+
+// ===== Arbitrary JSON with mapping =====
+
+func parseJSONMapping(data []byte, mapping *config.JSONMapping) ([]Announcement, error) {
+	var root interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&root); err != nil {
+		return nil, err
+	}
+
+	list, ok := lookupPath(root, mapping.Items).([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("items path %q is not an array", mapping.Items)
+	}
+
+	result := make([]Announcement, 0, len(list))
+	for _, item := range list {
+		result = append(result, Announcement{
+			ID:        stringAt(item, mapping.ID),
+			Title:     stringAt(item, mapping.Title),
+			Link:      stringAt(item, mapping.Link),
+			Published: parseTime(stringAt(item, mapping.Published), mapping.TimeFormat),
+			Body:      stringAt(item, mapping.Body),
+		})
+	}
+	return result, nil
+}
+
+// lookupPath resolves a JSONPath-like dotted path ("$.data.items[0].title").
+// Returns nil if any segment is missing.
+func lookupPath(v interface{}, path string) interface{} {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path == "" {
+		return v
+	}
+
+	for _, segment := range strings.Split(path, ".") {
+		name := segment
+		var indices []int
+		if i := strings.Index(segment, "["); i >= 0 {
+			name = segment[:i]
+			for _, part := range strings.Split(segment[i+1:], "[") {
+				n, err := strconv.Atoi(strings.TrimSuffix(part, "]"))
+				if err != nil {
+					return nil
+				}
+				indices = append(indices, n)
+			}
+		}
+
+		if name != "" {
+			obj, ok := v.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			v = obj[name]
+		}
+		for _, n := range indices {
+			list, ok := v.([]interface{})
+			if !ok || n < 0 || n >= len(list) {
+				return nil
+			}
+			v = list[n]
+		}
+	}
+	return v
+}
+
+// stringAt returns the value at path as a string ("" if missing or empty path).
+func stringAt(v interface{}, path string) string {
+	if path == "" {
+		return ""
+	}
+	switch value := lookupPath(v, path).(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// timeLayouts are the layouts tried for feed timestamps.
+var timeLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339Nano,
+	time.RFC3339,
+	time.RFC822Z,
+	time.RFC822,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// parseTime parses a timestamp with the given layout, common feed layouts or unix seconds.
+// Returns the zero time if it cannot be parsed.
+func parseTime(value, layout string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+	if layout != "" {
+		t, _ := time.Parse(layout, value)
+		return t
+	}
+	for _, l := range timeLayouts {
+		if t, err := time.Parse(l, value); err == nil {
+			return t
+		}
+	}
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(secs, 0)
+	}
+	return time.Time{}
+}
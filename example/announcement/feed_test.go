@@ -0,0 +1,143 @@
+package announcement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seencxy/plugGo/example/announcement/config"
+)
+
+const testRSS = `<?xml version="1.0"?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+  <channel>
+    <title>Releases</title>
+    <item>
+      <guid>release-2</guid>
+      <title> v2.0 released </title>
+      <link>https://example.com/v2</link>
+      <pubDate>Tue, 03 Mar 2026 10:00:00 +0000</pubDate>
+      <description>Short</description>
+      <content:encoded>Full notes</content:encoded>
+    </item>
+    <item>
+      <title>Maintenance</title>
+      <link>https://example.com/maintenance</link>
+      <description>Downtime tonight</description>
+    </item>
+  </channel>
+</rss>`
+
+const testAtom = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Status</title>
+  <entry>
+    <id>urn:status:1</id>
+    <title>Incident resolved</title>
+    <link rel="self" href="https://example.com/self"/>
+    <link href="https://example.com/incident"/>
+    <updated>2026-03-03T10:00:00Z</updated>
+    <summary>All good</summary>
+  </entry>
+</feed>`
+
+const testJSONFeed = `{
+  "version": "https://jsonfeed.org/version/1.1",
+  "items": [
+    {"id": "1", "url": "https://example.com/1", "title": "First", "summary": "S", "content_text": "Text",
+     "date_published": "2026-03-03T10:00:00Z"}
+  ]
+}`
+
+func TestParseFeedFormats(t *testing.T) {
+	published := time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		data   string
+		format string
+		want   []Announcement
+	}{
+		{"rss", testRSS, config.FormatAuto, []Announcement{
+			{ID: "release-2", Title: "v2.0 released", Link: "https://example.com/v2", Published: published, Body: "Full notes"},
+			{ID: "https://example.com/maintenance", Title: "Maintenance", Link: "https://example.com/maintenance", Body: "Downtime tonight"},
+		}},
+		{"atom", testAtom, "", []Announcement{
+			{ID: "urn:status:1", Title: "Incident resolved", Link: "https://example.com/incident", Published: published, Body: "All good"},
+		}},
+		{"jsonfeed", testJSONFeed, config.FormatAuto, []Announcement{
+			{ID: "1", Title: "First", Link: "https://example.com/1", Published: published, Body: "Text"},
+		}},
+		{"explicit format", testRSS, config.FormatRSS, nil}, // Only the count is checked
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := ParseFeed([]byte(tt.data), tt.format, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == nil {
+				if len(items) != 2 {
+					t.Errorf("got %d items, want 2", len(items))
+				}
+				return
+			}
+			if len(items) != len(tt.want) {
+				t.Fatalf("got %d items, want %d: %+v", len(items), len(tt.want), items)
+			}
+			for i, want := range tt.want {
+				if got := items[i]; got.ID != want.ID || got.Title != want.Title || got.Link != want.Link ||
+					!got.Published.Equal(want.Published) || got.Body != want.Body {
+					t.Errorf("item %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFeedJSONMapping(t *testing.T) {
+	data := `{"data": {"list": [
+		{"key": 7, "info": {"title": "Listing"}, "url": "https://example.com/7", "ts": "1772532000"},
+		{"key": 8, "info": {"title": "Delisting"}, "ts": "2026/03/03"}
+	]}}`
+	mapping := &config.JSONMapping{Items: "$.data.list", ID: "key", Title: "info.title", Link: "url", Published: "ts"}
+	items, err := ParseFeed([]byte(data), config.FormatAuto, mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].ID != "7" || items[0].Title != "Listing" || items[1].Title != "Delisting" {
+		t.Fatalf("items = %+v", items)
+	}
+	if !items[0].Published.Equal(time.Unix(1772532000, 0)) {
+		t.Errorf("unix published = %v", items[0].Published)
+	}
+	if !items[1].Published.IsZero() {
+		t.Errorf("unparsable published = %v, want zero", items[1].Published)
+	}
+
+	mapping.TimeFormat = "2006/01/02"
+	items, _ = ParseFeed([]byte(data), config.FormatJSON, mapping)
+	if want := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC); !items[1].Published.Equal(want) {
+		t.Errorf("published with layout = %v, want %v", items[1].Published, want)
+	}
+
+	if _, err := ParseFeed([]byte(data), config.FormatJSON, &config.JSONMapping{Items: "data"}); err == nil {
+		t.Error("items path to an object accepted")
+	}
+}
+
+func TestParseFeedErrors(t *testing.T) {
+	for name, data := range map[string]string{
+		"unknown xml":  `<html><body/></html>`,
+		"unknown json": `{"items": []}`,
+		"empty":        ``,
+	} {
+		if _, err := ParseFeed([]byte(data), config.FormatAuto, nil); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+	if _, err := ParseFeed([]byte(testRSS), config.FormatJSON, nil); err == nil {
+		t.Error("json without mapping: no error")
+	}
+	if _, err := ParseFeed([]byte(`<rss><channel><item>`), config.FormatRSS, nil); err == nil {
+		t.Error("truncated rss: no error")
+	}
+}
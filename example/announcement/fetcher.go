@@ -0,0 +1,151 @@
+package announcement
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/seencxy/plugGo/example/announcement/config"
+)
+
+// maxFeedSize limits how much of a response body is read.
+const maxFeedSize = 10 << 20
+
+// defaultFetchTimeout is the HTTP timeout used when none is configured.
+const defaultFetchTimeout = 30 * time.Second
+
+// validators are the conditional GET validators of a source's last response.
+type validators struct {
+	etag         string
+	lastModified string
+}
+
+// Fetcher fetches and parses announcement sources.
+// It remembers ETag / Last-Modified per source URL and sends conditional requests,
+// so unchanged feeds cost a 304 and no parsing.
+type Fetcher struct {
+	client     *http.Client
+	userAgent  string
+	validators map[string]validators     // key: source URL
+	recent     map[string][]Announcement // Items of the last successful fetch, key: source URL
+	mu         sync.Mutex
+}
+
+// NewFetcher creates a fetcher using the given HTTP client (a default client if nil).
+// Requests are bounded by the source timeout, not by a client timeout.
+func NewFetcher(client *http.Client) *Fetcher {
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &Fetcher{
+		client:     client,
+		userAgent:  fmt.Sprintf("plugGo-%s/%s", PluginName, PluginVersion),
+		validators: make(map[string]validators),
+		recent:     make(map[string][]Announcement),
+	}
+}
+
+// Fetch downloads and parses a source.
+// Returns (nil, false, nil) when the server reports the feed unchanged (304 Not Modified).
+func (f *Fetcher) Fetch(ctx context.Context, source config.Source) ([]Announcement, bool, error) {
+	timeout := source.Timeout
+	if timeout <= 0 {
+		timeout = defaultFetchTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.URL, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid request: %w", err)
+	}
+	req.Header.Set("User-Agent", f.userAgent)
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/feed+json, application/json;q=0.9, */*;q=0.8")
+
+	f.mu.Lock()
+	v := f.validators[source.URL]
+	f.mu.Unlock()
+	if v.etag != "" {
+		req.Header.Set("If-None-Match", v.etag)
+	}
+	if v.lastModified != "" {
+		req.Header.Set("If-Modified-Since", v.lastModified)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, false, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, false, &HTTPError{StatusCode: resp.StatusCode, Header: resp.Header}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedSize))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	items, err := ParseFeed(data, source.Format, source.Mapping)
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range items {
+		items[i].Source = source.Name
+	}
+
+	// Remember validators only after a successful parse
+	f.mu.Lock()
+	f.validators[source.URL] = validators{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}
+	f.recent[source.URL] = items
+	f.mu.Unlock()
+
+	return items, true, nil
+}
+
+// Recent returns the items of the last successful fetch of a source URL.
+func (f *Fetcher) Recent(url string) []Announcement {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Announcement(nil), f.recent[url]...)
+}
+
+// HTTPError is returned for non-2xx responses.
+type HTTPError struct {
+	StatusCode int
+	Header     http.Header
+}
+
+// Error implements the error interface.
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("unexpected HTTP status %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// RetryAfter returns the delay requested by a Retry-After header (seconds or HTTP date),
+// or 0 if there is none.
+func (e *HTTPError) RetryAfter() time.Duration {
+	value := e.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
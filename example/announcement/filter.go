@@ -0,0 +1,320 @@
+package announcement
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/seencxy/plugGo/example/announcement/config"
+)
+
+// defaultFilterFields are matched when no fields are configured.
+var defaultFilterFields = []string{config.FieldTitle, config.FieldBody}
+
+// Filter decides which announcements are handled.
+// A nil Filter passes everything.
+type Filter struct {
+	include []*rule // Any must match (if set)
+	exclude []*rule // None may match
+	match   *rule   // Must match (if set)
+}
+
+// CompileFilter compiles a filters config.
+func CompileFilter(cfg config.Filters) (*Filter, error) {
+	if cfg.Empty() {
+		return nil, nil
+	}
+
+	fields := cfg.Fields
+	if len(fields) == 0 {
+		fields = defaultFilterFields
+	}
+	if err := checkFields(fields); err != nil {
+		return nil, err
+	}
+
+	f := &Filter{}
+	for _, keyword := range append(append([]string{}, cfg.Keywords...), cfg.Include...) {
+		f.include = append(f.include, newContainsRule([]string{keyword}, fields, cfg.CaseSensitive))
+	}
+	for _, keyword := range cfg.Exclude {
+		f.exclude = append(f.exclude, newContainsRule([]string{keyword}, fields, cfg.CaseSensitive))
+	}
+	if cfg.Match != nil {
+		match, err := compileRule(*cfg.Match, fields, cfg.CaseSensitive, "match")
+		if err != nil {
+			return nil, err
+		}
+		f.match = match
+	}
+	return f, nil
+}
+
+// Match reports whether an announcement passes, with a human-readable reason.
+func (f *Filter) Match(a Announcement) (bool, string) {
+	if f == nil {
+		return true, "no filters"
+	}
+
+	var reasons []string
+	if len(f.include) > 0 {
+		matched := false
+		for _, r := range f.include {
+			if ok, reason := r.eval(a); ok {
+				matched = true
+				reasons = append(reasons, reason)
+				break
+			}
+		}
+		if !matched {
+			return false, "no include keyword found"
+		}
+	}
+	for _, r := range f.exclude {
+		if ok, reason := r.eval(a); ok {
+			return false, "excluded: " + reason
+		}
+	}
+	if f.match != nil {
+		ok, reason := f.match.eval(a)
+		if !ok {
+			return false, "match rule not satisfied: " + reason
+		}
+		reasons = append(reasons, reason)
+	}
+	if len(reasons) == 0 {
+		return true, "not excluded"
+	}
+	return true, strings.Join(reasons, "; ")
+}
+
+// ruleKind is the kind of a compiled rule.
+type ruleKind int
+
+const (
+	ruleAll ruleKind = iota
+	ruleAny
+	ruleNot
+	ruleContains
+	ruleRegex
+)
+
+// rule is a compiled config.Rule.
+type rule struct {
+	kind          ruleKind
+	children      []*rule
+	keywords      []string // Lowercased unless caseSensitive
+	pattern       *regexp.Regexp
+	fields        []string
+	caseSensitive bool
+}
+
+func newContainsRule(keywords, fields []string, caseSensitive bool) *rule {
+	r := &rule{kind: ruleContains, fields: fields, caseSensitive: caseSensitive}
+	for _, k := range keywords {
+		if !caseSensitive {
+			k = strings.ToLower(k)
+		}
+		r.keywords = append(r.keywords, k)
+	}
+	return r
+}
+
+// compileRule compiles a rule; path locates it in error messages.
+func compileRule(cfg config.Rule, fields []string, caseSensitive bool, path string) (*rule, error) {
+	set := 0
+	for _, ok := range []bool{len(cfg.All) > 0, len(cfg.Any) > 0, cfg.Not != nil, len(cfg.Contains) > 0, cfg.Regex != ""} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, fmt.Errorf("%s: exactly one of all, any, not, contains or regex must be set", path)
+	}
+
+	if len(cfg.Fields) > 0 {
+		if err := checkFields(cfg.Fields); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		fields = cfg.Fields
+	}
+	if cfg.CaseSensitive != nil {
+		caseSensitive = *cfg.CaseSensitive
+	}
+
+	switch {
+	case len(cfg.All) > 0 || len(cfg.Any) > 0:
+		r := &rule{kind: ruleAll}
+		children, name := cfg.All, "all"
+		if len(cfg.Any) > 0 {
+			r.kind, children, name = ruleAny, cfg.Any, "any"
+		}
+		for i, child := range children {
+			c, err := compileRule(child, fields, caseSensitive, fmt.Sprintf("%s.%s[%d]", path, name, i))
+			if err != nil {
+				return nil, err
+			}
+			r.children = append(r.children, c)
+		}
+		return r, nil
+	case cfg.Not != nil:
+		c, err := compileRule(*cfg.Not, fields, caseSensitive, path+".not")
+		if err != nil {
+			return nil, err
+		}
+		return &rule{kind: ruleNot, children: []*rule{c}}, nil
+	case len(cfg.Contains) > 0:
+		return newContainsRule(cfg.Contains, fields, caseSensitive), nil
+	default:
+		expr := cfg.Regex
+		if !caseSensitive {
+			expr = "(?i)" + expr
+		}
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid regex: %w", path, err)
+		}
+		return &rule{kind: ruleRegex, pattern: pattern, fields: fields, caseSensitive: caseSensitive}, nil
+	}
+}
+
+// eval evaluates the rule, returning what matched (or why nothing did).
+func (r *rule) eval(a Announcement) (bool, string) {
+	switch r.kind {
+	case ruleAll:
+		reasons := make([]string, 0, len(r.children))
+		for _, c := range r.children {
+			ok, reason := c.eval(a)
+			if !ok {
+				return false, reason
+			}
+			reasons = append(reasons, reason)
+		}
+		return true, strings.Join(reasons, " and ")
+	case ruleAny:
+		reasons := make([]string, 0, len(r.children))
+		for _, c := range r.children {
+			ok, reason := c.eval(a)
+			if ok {
+				return true, reason
+			}
+			reasons = append(reasons, reason)
+		}
+		return false, strings.Join(reasons, " and ")
+	case ruleNot:
+		ok, reason := r.children[0].eval(a)
+		return !ok, "not (" + reason + ")"
+	case ruleContains:
+		for _, field := range r.fields {
+			value := fieldValue(a, field)
+			if !r.caseSensitive {
+				value = strings.ToLower(value)
+			}
+			for _, k := range r.keywords {
+				if strings.Contains(value, k) {
+					return true, fmt.Sprintf("%s contains %q", field, k)
+				}
+			}
+		}
+		return false, fmt.Sprintf("%s contains none of %q", strings.Join(r.fields, "/"), r.keywords)
+	default:
+		for _, field := range r.fields {
+			if r.pattern.MatchString(fieldValue(a, field)) {
+				return true, fmt.Sprintf("%s matches /%s/", field, r.pattern)
+			}
+		}
+		return false, fmt.Sprintf("%s doesn't match /%s/", strings.Join(r.fields, "/"), r.pattern)
+	}
+}
+
+// fieldValue returns the announcement field a filter matches.
+func fieldValue(a Announcement, field string) string {
+	switch field {
+	case config.FieldTitle:
+		return a.Title
+	case config.FieldBody:
+		return a.Body
+	case config.FieldLink:
+		return a.Link
+	}
+	return ""
+}
+
+// checkFields validates filter field names.
+func checkFields(fields []string) error {
+	for _, field := range fields {
+		switch field {
+		case config.FieldTitle, config.FieldBody, config.FieldLink:
+		default:
+			return fmt.Errorf("unknown field %q (title, body or link)", field)
+		}
+	}
+	return nil
+}
+
+// filterSet holds the compiled instance and per-source filters of a config.
+type filterSet struct {
+	instance *Filter
+	sources  map[string]*Filter // key: source name
+}
+
+// compileFilters compiles the instance filters and the filters of every source.
+func compileFilters(cfg *config.Config) (*filterSet, error) {
+	instance, err := CompileFilter(cfg.Filters)
+	if err != nil {
+		return nil, fmt.Errorf("filters: %w", err)
+	}
+
+	set := &filterSet{instance: instance, sources: make(map[string]*Filter)}
+	for i, source := range cfg.Sources {
+		if source.Filters == nil {
+			continue
+		}
+		f, err := CompileFilter(*source.Filters)
+		if err != nil {
+			return nil, fmt.Errorf("sources[%d].filters: %w", i, err)
+		}
+		set.sources[source.Name] = f
+	}
+	return set, nil
+}
+
+// match applies the instance filters, then the filters of the source.
+func (s *filterSet) match(source string, a Announcement) (bool, string) {
+	ok, reason := s.instance.Match(a)
+	if !ok {
+		return false, reason
+	}
+	f := s.sources[source]
+	if f == nil {
+		return true, reason
+	}
+	ok, sourceReason := f.Match(a)
+	if !ok {
+		return false, "source " + sourceReason
+	}
+	return true, reason + "; source " + sourceReason
+}
+
+// FilterMatch is the dry-run result for one announcement.
+type FilterMatch struct {
+	Item    Announcement
+	Matched bool
+	Reason  string
+}
+
+// DryRunFilters evaluates the filters of cfg against announcements without handling them.
+// Items are matched against the instance filters and the filters of their source.
+func DryRunFilters(cfg *config.Config, items []Announcement) ([]FilterMatch, error) {
+	filters, err := compileFilters(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]FilterMatch, 0, len(items))
+	for _, item := range items {
+		ok, reason := filters.match(item.Source, item)
+		result = append(result, FilterMatch{Item: item, Matched: ok, Reason: reason})
+	}
+	return result, nil
+}
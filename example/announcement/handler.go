@@ -1,12 +1,17 @@
 package announcement
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"sync"
 	"time"
 
 	"github.com/seencxy/plugGo"
 	"github.com/seencxy/plugGo/example/announcement/config"
+	"github.com/seencxy/plugGo/example/announcement/store"
+	"github.com/seencxy/plugGo/schedule"
 )
 
 // Monitor is the announcement monitor.
@@ -16,26 +21,141 @@ type Monitor struct {
 	stopCh  chan struct{}
 	wg      sync.WaitGroup // Wait for all goroutines to exit
 	stopped bool
+	cfgMu   sync.RWMutex // Protects cfg for hot reload
+
+	fetcher *Fetcher           // Fetches and parses sources
+	ctx     context.Context    // Cancelled on stop to abort in-flight requests
+	cancel  context.CancelFunc // Cancels ctx
+
+	seen       store.Store // Remembers announcements already handled
+	instanceID string      // Instance part of the seen-store key
+	baseline   bool        // Record the first fetch of a source without handling it
+
+	dispatcher *Dispatcher // Delivers new announcements, protected by cfgMu (nil: log only)
+	filters    *filterSet  // Compiled filters of cfg, protected by cfgMu
+
+	health *healthTracker // Per-source polling results
+	clock  schedule.Clock // Time source of the polling schedule
+}
+
+// MonitorOption is a monitor configuration option function.
+type MonitorOption func(*Monitor)
+
+// WithFetcher sets the fetcher, e.g. to share conditional GET state across monitor rebuilds.
+func WithFetcher(fetcher *Fetcher) MonitorOption {
+	return func(m *Monitor) {
+		m.fetcher = fetcher
+	}
+}
+
+// WithSeenStore sets the seen-item store, so announcements handled before a
+// restart or reload are not handled again.
+// With baseline, the items found on a source's first fetch are recorded without being handled.
+func WithSeenStore(seen store.Store, instanceID string, baseline bool) MonitorOption {
+	return func(m *Monitor) {
+		m.seen = seen
+		m.instanceID = instanceID
+		m.baseline = baseline
+	}
+}
+
+// withHealth sets the tracker poll results are recorded in.
+func withHealth(health *healthTracker) MonitorOption {
+	return func(m *Monitor) {
+		m.health = health
+	}
+}
+
+// WithClock sets the clock polls are scheduled with, e.g. a schedule.FakeClock in tests.
+func WithClock(clock schedule.Clock) MonitorOption {
+	return func(m *Monitor) {
+		m.clock = clock
+	}
+}
+
+// WithDispatcher sets the dispatcher new announcements are delivered to.
+func WithDispatcher(dispatcher *Dispatcher) MonitorOption {
+	return func(m *Monitor) {
+		m.dispatcher = dispatcher
+	}
 }
 
 // NewMonitor creates a new monitor instance.
-func NewMonitor(cfg *config.Config, logger plugGo.Logger) *Monitor {
-	return &Monitor{
+func NewMonitor(cfg *config.Config, logger plugGo.Logger, opts ...MonitorOption) *Monitor {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &Monitor{
 		cfg:    cfg,
 		logger: logger,
 		stopCh: make(chan struct{}),
+		ctx:    ctx,
+		cancel: cancel,
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.fetcher == nil {
+		m.fetcher = NewFetcher(nil)
+	}
+	if m.clock == nil {
+		m.clock = schedule.RealClock
+	}
+	if m.health == nil {
+		m.health = newHealthTracker()
+	}
+	if m.seen == nil {
+		m.seen = store.NewMemoryStore(store.Retention{})
+		m.baseline = true
+	}
+	return m
+}
+
+// SetConfig hot-swaps the config used by running source pollers.
+// Only fields that don't affect the pollers themselves (filters, notifications) take effect.
+// The running config is kept if the new filters don't compile.
+func (m *Monitor) SetConfig(cfg *config.Config) error {
+	filters, err := compileFilters(cfg)
+	if err != nil {
+		return err
+	}
+
+	m.cfgMu.Lock()
+	defer m.cfgMu.Unlock()
+	m.cfg = cfg
+	m.filters = filters
+	return nil
+}
+
+// SetDispatcher hot-swaps the dispatcher, e.g. after the notifications config changed.
+func (m *Monitor) SetDispatcher(dispatcher *Dispatcher) {
+	m.cfgMu.Lock()
+	defer m.cfgMu.Unlock()
+	m.dispatcher = dispatcher
+}
+
+// handlers returns the current dispatcher and filters.
+func (m *Monitor) handlers() (*Dispatcher, *filterSet) {
+	m.cfgMu.RLock()
+	defer m.cfgMu.RUnlock()
+	return m.dispatcher, m.filters
+}
+
+// config returns the current config.
+func (m *Monitor) config() *config.Config {
+	m.cfgMu.RLock()
+	defer m.cfgMu.RUnlock()
+	return m.cfg
 }
 
 // Start starts monitoring.
 func (m *Monitor) Start() error {
-	if !m.cfg.Enabled {
-		return fmt.Errorf("plugin is disabled")
+	if err := m.SetConfig(m.cfg); err != nil {
+		return err
 	}
 
 	m.logger.Info(fmt.Sprintf("Starting monitor for %d sources", len(m.cfg.Sources)))
 	for _, source := range m.cfg.Sources {
 		m.logger.Debug("Starting monitor for source:", source.Name)
+		addHostSource(hostSource{m, source.Name}, source.URL, source.RateLimit)
 		m.wg.Add(1) // Count before starting goroutine
 		go m.monitorSource(source)
 	}
@@ -51,6 +171,7 @@ func (m *Monitor) Stop() error {
 
 	m.logger.Info("Stopping monitor")
 	close(m.stopCh)
+	m.cancel()
 
 	// Wait for all goroutines to exit
 	m.wg.Wait()
@@ -61,25 +182,94 @@ func (m *Monitor) Stop() error {
 }
 
 // monitorSource monitors a single announcement source.
+// The first poll happens immediately; later polls follow the schedule (or the
+// interval plus jitter), backing off exponentially while the source fails.
+// The source's host rate limit is dropped when it returns.
 func (m *Monitor) monitorSource(source config.Source) {
 	defer m.wg.Done() // Decrement counter when goroutine exits
+	defer removeHostSource(hostSource{m, source.Name}, source.URL)
+
+	var sched schedule.Schedule
+	if source.Schedule != "" {
+		var err error
+		if sched, err = schedule.Parse(source.Schedule); err != nil {
+			m.logger.Error(fmt.Sprintf("Invalid schedule of source %s: %v", source.Name, err))
+			return
+		}
+	}
 
-	ticker := time.NewTicker(time.Duration(source.Interval) * time.Second)
-	defer ticker.Stop()
+	timer := m.clock.NewTimer(0)
+	defer timer.Stop()
 
 	for {
 		select {
 		case <-m.stopCh:
 			m.logger.Debug("Monitor goroutine exiting for source:", source.Name)
 			return
-		case <-ticker.C:
-			// Here should implement actual announcement fetching logic
-			// Example code only prints logs
-			m.checkAnnouncements(source)
+		case <-timer.C():
+			err := m.checkAnnouncements(source)
+			if m.ctx.Err() != nil {
+				continue // Stopping, the result doesn't count
+			}
+			now := m.clock.Now()
+			failures := m.health.record(source.Name, source.URL, now, err)
+			delay := nextPollDelay(source, sched, now, failures, err)
+			m.health.scheduled(source.Name, now.Add(delay))
+			timer.Reset(delay)
 		}
 	}
 }
 
+// defaultPollMaxBackoff caps the polling interval of a failing source when MaxBackoff is not set.
+const defaultPollMaxBackoff = time.Hour
+
+// nextPollDelay returns the wait from now before the next poll of a source.
+// Scheduled sources poll at the next activation. Interval sources double the
+// interval after each of n consecutive failures, up to MaxBackoff.
+// Both honour a longer Retry-After from the server.
+func nextPollDelay(source config.Source, sched schedule.Schedule, now time.Time, failures int, err error) time.Duration {
+	var retryAfter time.Duration
+	var httpErr *HTTPError
+	if failures > 0 && errors.As(err, &httpErr) {
+		retryAfter = httpErr.RetryAfter()
+	}
+
+	var delay time.Duration
+	if sched != nil {
+		next := sched.Next(now)
+		if retryAfter > 0 && next.Sub(now) < retryAfter {
+			next = sched.Next(now.Add(retryAfter))
+		}
+		delay = next.Sub(now)
+	} else {
+		interval := time.Duration(source.Interval) * time.Second
+		delay = interval
+		if failures > 0 {
+			maxBackoff := source.MaxBackoff
+			if maxBackoff <= 0 {
+				maxBackoff = defaultPollMaxBackoff
+			}
+			if maxBackoff < interval {
+				maxBackoff = interval
+			}
+			for i := 0; i < failures && delay < maxBackoff; i++ {
+				delay *= 2
+			}
+			if delay > maxBackoff {
+				delay = maxBackoff
+			}
+		}
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+	}
+
+	if source.Jitter > 0 {
+		delay += rand.N(source.Jitter)
+	}
+	return delay
+}
+
 // StopWithTimeout stops the monitor with a timeout.
 func (m *Monitor) StopWithTimeout(timeout time.Duration) error {
 	if m.stopped {
@@ -88,6 +278,7 @@ func (m *Monitor) StopWithTimeout(timeout time.Duration) error {
 
 	m.logger.Info(fmt.Sprintf("Stopping monitor with timeout: %v", timeout))
 	close(m.stopCh)
+	m.cancel()
 
 	// Wait with timeout
 	done := make(chan struct{})
@@ -108,9 +299,86 @@ func (m *Monitor) StopWithTimeout(timeout time.Duration) error {
 	}
 }
 
-// checkAnnouncements checks for announcement updates.
-func (m *Monitor) checkAnnouncements(source config.Source) {
-	// TODO: Implement actual announcement fetching, filtering and notification logic
-	// This is just an example
+// checkAnnouncements fetches a source and handles its announcements.
+// Returns the fetch error, if any.
+func (m *Monitor) checkAnnouncements(source config.Source) error {
 	m.logger.Trace(fmt.Sprintf("Checking announcements from source: %s, URL: %s", source.Name, source.URL))
+
+	if err := waitHost(m.ctx, source.URL); err != nil {
+		return err
+	}
+
+	items, modified, err := m.fetcher.Fetch(m.ctx, source)
+	if err != nil {
+		if m.ctx.Err() == nil {
+			m.logger.Warn(fmt.Sprintf("Failed to fetch source %s: %v", source.Name, err))
+		}
+		return err
+	}
+	if !modified {
+		m.logger.Trace(fmt.Sprintf("Source %s not modified", source.Name))
+		return nil
+	}
+
+	m.handleAnnouncements(source, items)
+	return nil
+}
+
+// handleAnnouncements processes the announcements fetched from a source.
+// Only announcements not seen before are handled; they are marked as seen first,
+// so a crash may lose one but a restart never repeats one.
+// New announcements rejected by the filters are marked as seen too, so loosening
+// the filters later doesn't flood notifications with old items.
+func (m *Monitor) handleAnnouncements(source config.Source, items []Announcement) {
+	m.logger.Debug(fmt.Sprintf("Fetched %d announcements from source %s", len(items), source.Name))
+
+	key := store.Key{Instance: m.instanceID, Source: source.Name}
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+
+	unseen, known, err := m.seen.Unseen(key, ids)
+	if err != nil {
+		m.logger.Error(fmt.Sprintf("Failed to read seen state of source %s: %v", source.Name, err))
+		return
+	}
+
+	now := time.Now()
+	if !known && m.baseline {
+		if err := m.seen.MarkSeen(key, ids, now); err != nil {
+			m.logger.Error(fmt.Sprintf("Failed to record baseline of source %s: %v", source.Name, err))
+			return
+		}
+		m.logger.Info(fmt.Sprintf("Recorded baseline of %d announcements from source %s", len(unseen), source.Name))
+		return
+	}
+	if len(unseen) == 0 {
+		return
+	}
+
+	if err := m.seen.MarkSeen(key, unseen, now); err != nil {
+		m.logger.Error(fmt.Sprintf("Failed to record seen state of source %s: %v", source.Name, err))
+		return
+	}
+
+	dispatcher, filters := m.handlers()
+	isNew := make(map[string]bool, len(unseen))
+	for _, id := range unseen {
+		isNew[id] = true
+	}
+	for _, item := range items {
+		if !isNew[item.ID] {
+			continue
+		}
+		delete(isNew, item.ID) // Handle duplicate IDs within one fetch once
+		if ok, reason := filters.match(source.Name, item); !ok {
+			m.logger.Debug(fmt.Sprintf("[%s] Filtered out %q: %s", source.Name, item.Title, reason))
+			continue
+		}
+		m.logger.Info(fmt.Sprintf("[%s] New announcement: %s %s", source.Name, item.Title, item.Link))
+		if dispatcher != nil {
+			dispatcher.Dispatch(Message{Instance: m.instanceID, Announcement: item})
+		}
+	}
 }
@@ -0,0 +1,227 @@
+package announcement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seencxy/plugGo"
+	"github.com/seencxy/plugGo/example/announcement/config"
+	"github.com/seencxy/plugGo/example/announcement/store"
+	"github.com/seencxy/plugGo/pluggotest"
+	"github.com/seencxy/plugGo/schedule"
+)
+
+// feedServer serves an RSS feed whose items can be changed, with an ETag.
+type feedServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	titles   []string
+	requests int
+}
+
+func newFeedServer(t *testing.T, titles ...string) *feedServer {
+	s := &feedServer{titles: titles}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests++
+		etag := fmt.Sprintf(`"%d"`, len(s.titles))
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		fmt.Fprint(w, `<rss version="2.0"><channel>`)
+		for _, title := range s.titles {
+			fmt.Fprintf(w, `<item><guid>%s</guid><title>%s</title></item>`, title, title)
+		}
+		fmt.Fprint(w, `</channel></rss>`)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *feedServer) publish(title string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.titles = append(s.titles, title)
+}
+
+func TestFetchConditionalGet(t *testing.T) {
+	server := newFeedServer(t, "a", "b")
+	fetcher := NewFetcher(server.Client())
+	source := config.Source{Name: "test", URL: server.URL}
+
+	items, modified, err := fetcher.Fetch(context.Background(), source)
+	if err != nil || !modified || len(items) != 2 || items[0].Source != "test" {
+		t.Fatalf("Fetch = %+v, %v, %v", items, modified, err)
+	}
+	// Unchanged: the ETag gets a 304 and nothing to parse
+	if items, modified, err := fetcher.Fetch(context.Background(), source); err != nil || modified || items != nil {
+		t.Fatalf("Fetch of unchanged feed = %+v, %v, %v", items, modified, err)
+	}
+	if got := len(fetcher.Recent(server.URL)); got != 2 {
+		t.Errorf("recent = %d items, want 2", got)
+	}
+	server.publish("c")
+	if items, modified, _ := fetcher.Fetch(context.Background(), source); !modified || len(items) != 3 {
+		t.Errorf("Fetch after publish = %d items, modified %v", len(items), modified)
+	}
+}
+
+func TestFetchRetryAfter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, _, err := NewFetcher(server.Client()).Fetch(context.Background(), config.Source{URL: server.URL})
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Fetch = %v, want an HTTPError 503", err)
+	}
+	if got := httpErr.RetryAfter(); got != 2*time.Minute {
+		t.Errorf("RetryAfter = %v, want 2m", got)
+	}
+
+	date := &HTTPError{Header: http.Header{"Retry-After": {time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)}}}
+	if got := date.RetryAfter(); got < 59*time.Minute || got > time.Hour {
+		t.Errorf("RetryAfter of an HTTP date = %v, want about 1h", got)
+	}
+}
+
+func TestNextPollDelay(t *testing.T) {
+	now := time.Date(2026, 3, 3, 10, 1, 0, 0, time.UTC)
+	source := config.Source{Interval: 60, MaxBackoff: 5 * time.Minute}
+	fetchErr := errors.New("connection refused")
+	retryAfter := func(d string) error {
+		return &HTTPError{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {d}}}
+	}
+
+	tests := []struct {
+		name     string
+		failures int
+		err      error
+		want     time.Duration
+	}{
+		{"healthy", 0, nil, time.Minute},
+		{"first failure doubles", 1, fetchErr, 2 * time.Minute},
+		{"second failure doubles again", 2, fetchErr, 4 * time.Minute},
+		{"capped at MaxBackoff", 5, fetchErr, 5 * time.Minute},
+		{"longer Retry-After wins", 1, retryAfter("600"), 10 * time.Minute},
+		{"shorter Retry-After ignored", 2, retryAfter("30"), 4 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := nextPollDelay(source, nil, now, tt.failures, tt.err); got != tt.want {
+			t.Errorf("%s: delay = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// Without MaxBackoff the default cap applies
+	if got := nextPollDelay(config.Source{Interval: 60}, nil, now, 20, fetchErr); got != defaultPollMaxBackoff {
+		t.Errorf("default cap: delay = %v, want %v", got, defaultPollMaxBackoff)
+	}
+
+	// Scheduled sources poll at the next activation, or the first one after Retry-After
+	sched, err := schedule.Parse("*/10 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := nextPollDelay(source, sched, now, 1, fetchErr); got != 9*time.Minute {
+		t.Errorf("scheduled: delay = %v, want 9m", got)
+	}
+	if got := nextPollDelay(source, sched, now, 1, retryAfter("900")); got != 19*time.Minute {
+		t.Errorf("scheduled with Retry-After: delay = %v, want 19m", got)
+	}
+
+	// Jitter only adds
+	source.Jitter = time.Second
+	for i := 0; i < 20; i++ {
+		if got := nextPollDelay(source, nil, now, 0, nil); got < time.Minute || got >= time.Minute+time.Second {
+			t.Fatalf("jittered delay = %v", got)
+		}
+	}
+}
+
+func TestFirstPollRecordsBaseline(t *testing.T) {
+	server := newFeedServer(t, "old-1", "old-2")
+	source := config.Source{Name: "feed", URL: server.URL}
+	logger := pluggotest.NewLogger()
+	monitor := NewMonitor(&config.Config{Sources: []config.Source{source}}, logger, WithFetcher(NewFetcher(server.Client())))
+	if err := monitor.SetConfig(monitor.cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first poll only records what the feed already has
+	if err := monitor.checkAnnouncements(source); err != nil {
+		t.Fatal(err)
+	}
+	if announced := newAnnouncements(logger); len(announced) != 0 {
+		t.Fatalf("first poll announced %v", announced)
+	}
+	if !logger.Contains(plugGo.InfoLevel, "Recorded baseline of 2 announcements") {
+		t.Errorf("no baseline logged:\n%s", logger)
+	}
+
+	// Later polls announce only new items
+	server.publish("new-1")
+	if err := monitor.checkAnnouncements(source); err != nil {
+		t.Fatal(err)
+	}
+	if err := monitor.checkAnnouncements(source); err != nil { // 304
+		t.Fatal(err)
+	}
+	if got := newAnnouncements(logger); !equalStrings(got, []string{"new-1"}) {
+		t.Errorf("announced %v, want [new-1]", got)
+	}
+}
+
+func TestFirstPollWithoutBaselineAnnouncesEverything(t *testing.T) {
+	server := newFeedServer(t, "old-1", "old-2")
+	source := config.Source{Name: "feed", URL: server.URL}
+	logger := pluggotest.NewLogger()
+	monitor := NewMonitor(&config.Config{Sources: []config.Source{source}}, logger,
+		WithFetcher(NewFetcher(server.Client())),
+		WithSeenStore(store.NewMemoryStore(store.Retention{}), "test", false))
+	if err := monitor.SetConfig(monitor.cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := monitor.checkAnnouncements(source); err != nil {
+		t.Fatal(err)
+	}
+	if got := newAnnouncements(logger); !equalStrings(got, []string{"old-1", "old-2"}) {
+		t.Errorf("announced %v, want both items", got)
+	}
+}
+
+// newAnnouncements returns the titles of the announcements logged as new.
+func newAnnouncements(logger *pluggotest.Logger) []string {
+	var titles []string
+	for _, message := range logger.Messages(plugGo.InfoLevel) {
+		if _, rest, ok := strings.Cut(message, "New announcement: "); ok {
+			titles = append(titles, strings.TrimSpace(rest))
+		}
+	}
+	return titles
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package announcement
+
+import (
+	"sync"
+	"time"
+
+	"github.com/seencxy/plugGo"
+)
+
+// sourceHealth is the polling state of one source.
+type sourceHealth struct {
+	url                 string
+	lastCheck           time.Time
+	lastSuccess         time.Time
+	lastError           string
+	consecutiveFailures int
+	nextPoll            time.Time
+}
+
+// healthTracker records per-source polling results.
+// It is owned by the plugin so the state survives monitor rebuilds on reload.
+type healthTracker struct {
+	sources map[string]*sourceHealth // key: source name
+	mu      sync.Mutex
+}
+
+func newHealthTracker() *healthTracker {
+	return &healthTracker{sources: make(map[string]*sourceHealth)}
+}
+
+// get returns the state of a source, creating it if needed.
+// Note: caller must hold the lock (h.mu).
+func (h *healthTracker) get(name string) *sourceHealth {
+	s, ok := h.sources[name]
+	if !ok {
+		s = &sourceHealth{}
+		h.sources[name] = s
+	}
+	return s
+}
+
+// record stores the result of a poll made at now and returns the consecutive failures so far.
+func (h *healthTracker) record(name, url string, now time.Time, err error) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s := h.get(name)
+	s.url = url
+	s.lastCheck = now
+	if err != nil {
+		s.lastError = err.Error()
+		s.consecutiveFailures++
+	} else {
+		s.lastSuccess = s.lastCheck
+		s.lastError = ""
+		s.consecutiveFailures = 0
+	}
+	return s.consecutiveFailures
+}
+
+// scheduled stores when a source is polled next.
+func (h *healthTracker) scheduled(name string, next time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.get(name).nextPoll = next
+}
+
+// report returns the health of the named sources, in order.
+func (h *healthTracker) report(names []string) []plugGo.ComponentHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	result := make([]plugGo.ComponentHealth, 0, len(names))
+	for _, name := range names {
+		s := h.get(name)
+		details := map[string]string{"url": s.url}
+		if !s.nextPoll.IsZero() {
+			details["nextPoll"] = s.nextPoll.Format(time.RFC3339)
+		}
+		result = append(result, plugGo.ComponentHealth{
+			Name:                name,
+			Healthy:             s.consecutiveFailures == 0,
+			LastCheck:           s.lastCheck,
+			LastSuccess:         s.lastSuccess,
+			LastError:           s.lastError,
+			ConsecutiveFailures: s.consecutiveFailures,
+			Details:             details,
+		})
+	}
+	return result
+}
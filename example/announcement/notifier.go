@@ -0,0 +1,195 @@
+package announcement
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/seencxy/plugGo/example/announcement/config"
+)
+
+// Message is what notifiers deliver: a new announcement and the instance that found it.
+// Templates see the Announcement fields directly, e.g. {{.Title}} or {{.Link}}.
+type Message struct {
+	Instance string `json:"instance"`
+	Announcement
+}
+
+// Notifier delivers messages to one destination.
+// Implementations must be safe for concurrent use.
+type Notifier interface {
+	// Name returns the name used in logs and dead letters.
+	Name() string
+	// Notify delivers one message. Errors wrapped with Permanent are not retried.
+	Notify(ctx context.Context, msg Message) error
+}
+
+// permanentError marks an error retrying cannot fix.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not retryable (e.g. a rejected request or a broken template).
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
+
+// Default message templates.
+const (
+	defaultTextTemplate    = "{{if not .Published.IsZero}}{{.Published.Format \"2006-01-02 15:04\"}} {{end}}[{{.Source}}] {{.Title}} {{.Link}}\n"
+	defaultSubjectTemplate = "[{{.Source}}] {{.Title}}"
+	defaultEmailTemplate   = "{{.Title}}\n{{.Link}}\n\n{{.Body}}\n"
+)
+
+// templateFuncs are available to all notification templates.
+var templateFuncs = template.FuncMap{
+	// json encodes a value as JSON, for embedding strings in webhook bodies: {"text": {{json .Title}}}
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	// truncate shortens s to at most n runes
+	"truncate": func(n int, s string) string {
+		if r := []rune(s); len(r) > n {
+			return string(r[:n]) + "..."
+		}
+		return s
+	},
+}
+
+// parseTemplate parses a notification template, using fallback if text is empty.
+// Returns nil if both are empty.
+func parseTemplate(name, text, fallback string) (*template.Template, error) {
+	if text == "" {
+		text = fallback
+	}
+	if text == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s template: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// render executes a template with a message.
+// Template errors are permanent: retrying renders the same message again.
+func render(tmpl *template.Template, msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, msg); err != nil {
+		return nil, Permanent(fmt.Errorf("failed to render %s template: %w", tmpl.Name(), err))
+	}
+	return buf.Bytes(), nil
+}
+
+// NewNotifier creates a notifier from its config.
+// notifyCh is the plugin notify channel used by the channel type.
+func NewNotifier(cfg config.Notification, notifyCh chan any) (Notifier, error) {
+	name := cfg.Name
+	if name == "" {
+		name = cfg.Type
+	}
+
+	switch cfg.Type {
+	case config.NotifyWebhook:
+		return newWebhookNotifier(name, cfg)
+	case config.NotifyEmail:
+		return newEmailNotifier(name, cfg)
+	case config.NotifyFile, config.NotifyStdout:
+		return newFileNotifier(name, cfg)
+	case config.NotifyChannel:
+		if notifyCh == nil {
+			return nil, fmt.Errorf("notification %s: plugin has no notify channel", name)
+		}
+		return &channelNotifier{name: name, ch: notifyCh}, nil
+	default:
+		return nil, fmt.Errorf("notification %s: unsupported type %q", name, cfg.Type)
+	}
+}
+
+// validateNotification checks a notification config without opening anything.
+func validateNotification(cfg config.Notification) error {
+	switch cfg.Type {
+	case config.NotifyWebhook:
+		if cfg.URL == "" {
+			return fmt.Errorf("webhook requires a url")
+		}
+	case config.NotifyEmail:
+		if cfg.SMTP == nil || cfg.SMTP.Host == "" || cfg.SMTP.From == "" || len(cfg.SMTP.To) == 0 {
+			return fmt.Errorf("email requires smtp host, from and to")
+		}
+	case config.NotifyFile:
+		if cfg.Path == "" {
+			return fmt.Errorf("file requires a path")
+		}
+	case config.NotifyStdout, config.NotifyChannel:
+	default:
+		return fmt.Errorf("unsupported type %q", cfg.Type)
+	}
+
+	if _, err := parseTemplate("message", cfg.Template, ""); err != nil {
+		return err
+	}
+	if _, err := parseTemplate("subject", cfg.Subject, ""); err != nil {
+		return err
+	}
+	if cfg.Timeout < 0 || cfg.Concurrency < 0 || cfg.QueueSize < 0 {
+		return fmt.Errorf("timeout, concurrency and queueSize must not be negative")
+	}
+	if cfg.Retry.MaxAttempts < 0 || cfg.Retry.InitialBackoff < 0 || cfg.Retry.MaxBackoff < 0 {
+		return fmt.Errorf("retry settings must not be negative")
+	}
+	return nil
+}
+
+// channelNotifier pushes messages to the plugin notify channel.
+type channelNotifier struct {
+	name string
+	ch   chan any
+}
+
+func (n *channelNotifier) Name() string { return n.name }
+
+// Notify sends without blocking; a full channel is retried with backoff.
+func (n *channelNotifier) Notify(ctx context.Context, msg Message) error {
+	select {
+	case n.ch <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		return errors.New("notify channel full")
+	}
+}
+
+// backoff returns the wait before retry n (1-based).
+func backoff(policy config.Retry, n int) time.Duration {
+	wait := policy.InitialBackoff
+	for i := 1; i < n && wait < policy.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > policy.MaxBackoff {
+		wait = policy.MaxBackoff
+	}
+	return wait
+}
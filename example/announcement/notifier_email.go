@@ -0,0 +1,135 @@
+package announcement
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/seencxy/plugGo/example/announcement/config"
+)
+
+// defaultSMTPPort is used when the SMTP port is not configured.
+const defaultSMTPPort = 25
+
+// emailNotifier sends plain-text mail through an SMTP server.
+type emailNotifier struct {
+	name    string
+	smtp    config.SMTP
+	subject *template.Template
+	body    *template.Template
+}
+
+func newEmailNotifier(name string, cfg config.Notification) (*emailNotifier, error) {
+	if cfg.SMTP == nil {
+		return nil, fmt.Errorf("notification %s: email requires smtp settings", name)
+	}
+	subject, err := parseTemplate("subject", cfg.Subject, defaultSubjectTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("notification %s: %w", name, err)
+	}
+	body, err := parseTemplate("message", cfg.Template, defaultEmailTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("notification %s: %w", name, err)
+	}
+
+	settings := *cfg.SMTP
+	if settings.Port == 0 {
+		settings.Port = defaultSMTPPort
+	}
+	return &emailNotifier{name: name, smtp: settings, subject: subject, body: body}, nil
+}
+
+func (n *emailNotifier) Name() string { return n.name }
+
+// Notify sends one mail to all recipients. 5xx SMTP replies are permanent.
+func (n *emailNotifier) Notify(ctx context.Context, msg Message) error {
+	subject, err := render(n.subject, msg)
+	if err != nil {
+		return err
+	}
+	body, err := render(n.body, msg)
+	if err != nil {
+		return err
+	}
+
+	err = n.send(ctx, n.compose(strings.TrimSpace(string(subject)), body))
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) && protoErr.Code >= 500 {
+		return Permanent(err)
+	}
+	return err
+}
+
+// compose builds the mail headers and body.
+func (n *emailNotifier) compose(subject string, body []byte) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", n.smtp.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(n.smtp.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	buf.Write(bytes.ReplaceAll(bytes.ReplaceAll(body, []byte("\r\n"), []byte("\n")), []byte("\n"), []byte("\r\n")))
+	return buf.Bytes()
+}
+
+// send delivers a composed mail, bounded by ctx.
+func (n *emailNotifier) send(ctx context.Context, data []byte) error {
+	addr := net.JoinHostPort(n.smtp.Host, strconv.Itoa(n.smtp.Port))
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, n.smtp.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.smtp.Host}); err != nil {
+			return err
+		}
+	}
+	if n.smtp.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.smtp.Username, n.smtp.Password, n.smtp.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(n.smtp.From); err != nil {
+		return err
+	}
+	for _, to := range n.smtp.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
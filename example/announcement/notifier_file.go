@@ -0,0 +1,65 @@
+package announcement
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"text/template"
+
+	"github.com/seencxy/plugGo/example/announcement/config"
+)
+
+// fileNotifier appends formatted messages to a file or writes them to stdout.
+type fileNotifier struct {
+	name string
+	path string // Empty for stdout
+	tmpl *template.Template
+	mu   sync.Mutex // Keeps concurrent messages from interleaving
+}
+
+func newFileNotifier(name string, cfg config.Notification) (*fileNotifier, error) {
+	tmpl, err := parseTemplate("message", cfg.Template, defaultTextTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("notification %s: %w", name, err)
+	}
+	n := &fileNotifier{name: name, tmpl: tmpl}
+	if cfg.Type == config.NotifyFile {
+		n.path = cfg.Path
+	}
+	return n, nil
+}
+
+func (n *fileNotifier) Name() string { return n.name }
+
+// Notify writes the rendered message. The file is opened per message so it can be rotated externally.
+func (n *fileNotifier) Notify(ctx context.Context, msg Message) error {
+	data, err := render(n.tmpl, msg)
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 || data[len(data)-1] != '\n' {
+		data = append(data, '\n')
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var w io.Writer = os.Stdout
+	if n.path != "" {
+		if err := os.MkdirAll(filepath.Dir(n.path), 0o755); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(n.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	_, err = w.Write(data)
+	return err
+}
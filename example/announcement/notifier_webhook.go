@@ -0,0 +1,89 @@
+package announcement
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"text/template"
+
+	"github.com/seencxy/plugGo/example/announcement/config"
+)
+
+// webhookNotifier posts a JSON body to a URL.
+type webhookNotifier struct {
+	name    string
+	url     string
+	method  string
+	headers map[string]string
+	body    *template.Template // nil: the message as JSON
+	client  *http.Client
+}
+
+func newWebhookNotifier(name string, cfg config.Notification) (*webhookNotifier, error) {
+	body, err := parseTemplate("message", cfg.Template, "")
+	if err != nil {
+		return nil, fmt.Errorf("notification %s: %w", name, err)
+	}
+	method := cfg.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	return &webhookNotifier{
+		name:    name,
+		url:     cfg.URL,
+		method:  method,
+		headers: cfg.Headers,
+		body:    body,
+		client:  &http.Client{}, // Attempts are bounded by the dispatcher context
+	}, nil
+}
+
+func (n *webhookNotifier) Name() string { return n.name }
+
+// Notify sends the rendered body. 4xx responses other than 408 and 429 are permanent.
+func (n *webhookNotifier) Notify(ctx context.Context, msg Message) error {
+	var (
+		body []byte
+		err  error
+	)
+	if n.body == nil {
+		body, err = json.Marshal(msg)
+	} else {
+		body, err = render(n.body, msg)
+	}
+	if err != nil {
+		return err
+	}
+	if !json.Valid(body) {
+		return Permanent(fmt.Errorf("webhook body is not valid JSON: %.100s", body))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, n.method, n.url, bytes.NewReader(body))
+	if err != nil {
+		return Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range n.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil
+	}
+	err = &HTTPError{StatusCode: resp.StatusCode, Header: resp.Header}
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return Permanent(err)
+	}
+	return err
+}
@@ -3,11 +3,14 @@ package announcement
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"sync"
 	"time"
 
 	"github.com/seencxy/plugGo"
+	plugGoConfig "github.com/seencxy/plugGo/config"
 	"github.com/seencxy/plugGo/example/announcement/config"
+	"github.com/seencxy/plugGo/example/announcement/store"
 )
 
 // Plugin is the announcement monitor plugin.
@@ -17,10 +20,13 @@ type Plugin struct {
 	pluginType string                  // Plugin type name
 	cfg        *config.Config          // Current config
 	logger     plugGo.Logger           // Logger
-	monitor    *Monitor                // Monitor
-	status     plugGo.PluginStatus     // Current status
-	statusCh   chan plugGo.StatusEvent // Status notification channel
+	monitor    *Monitor                // Monitor, nil while stopped
+	statusCh   chan plugGo.StatusEvent // Reports failures while running to the instance
 	notifyCh   chan any                // External notification channel
+	fetcher    *Fetcher                // Shared by monitors so conditional GET state survives reloads
+	seen       store.Store             // Shared by monitors so seen state survives reloads and restarts
+	dispatcher *Dispatcher             // Delivers notifications while running
+	health     *healthTracker          // Per-source polling health, kept across reloads
 	mu         sync.RWMutex            // Protects concurrent access
 }
 
@@ -39,58 +45,50 @@ func (p *Plugin) Version() string {
 	return PluginVersion
 }
 
-// Status returns the current plugin status.
+// Status reports whether the monitor is running. The lifecycle status is
+// tracked by the plugGo.PluginInstance wrapping the plugin.
 func (p *Plugin) Status() plugGo.PluginStatus {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
-	return p.status
+	if p.monitor != nil {
+		return plugGo.StatusRunning
+	}
+	return plugGo.StatusStopped
 }
 
-// StatusNotify returns a read-only channel for receiving status change events.
+// StatusNotify returns the channel reporting failures while running, which move
+// the instance to Error. Poll errors are not failures, they are reported per
+// source through Health.
 func (p *Plugin) StatusNotify() <-chan plugGo.StatusEvent {
 	return p.statusCh
 }
 
 // GetNotifyChannel returns the plugin's notification channel.
-// Returns nil if the plugin doesn't support external notifications.
+// Notifications of type "channel" push a Message for every new announcement.
 func (p *Plugin) GetNotifyChannel() chan any {
 	return p.notifyCh
 }
 
-// updateStatus updates the plugin status and sends notification.
-// Note: caller must hold the lock (p.mu).
-func (p *Plugin) updateStatus(newStatus plugGo.PluginStatus, err error) {
-	if p.status != newStatus {
-		p.status = newStatus
-		// Send status event non-blocking
-		select {
-		case p.statusCh <- plugGo.StatusEvent{Status: newStatus, Error: err}:
-		default:
-			// Channel full, skip this event
-			p.logger.Warn("Status channel full, event dropped")
-		}
-	}
-}
-
 // Start starts the plugin with context.
 func (p *Plugin) Start(ctx context.Context) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	if !p.cfg.Enabled {
-		p.logger.Info("Plugin is disabled, skipping start")
-		return nil
-	}
-
 	// Create and start monitor
-	p.monitor = NewMonitor(p.cfg, p.logger)
+	if err := p.openStore(); err != nil {
+		p.logger.Error("Failed to open seen store:", err)
+		return err
+	}
+	if err := p.openDispatcher(); err != nil {
+		p.logger.Error("Failed to create notifiers:", err)
+		return err
+	}
+	p.monitor = p.newMonitor()
 	if err := p.monitor.Start(); err != nil {
 		p.logger.Error("Failed to start monitor:", err)
-		p.updateStatus(plugGo.StatusError, err)
 		return fmt.Errorf("failed to start monitor: %w", err)
 	}
 
-	p.updateStatus(plugGo.StatusRunning, nil)
 	p.logger.Info("Plugin started successfully")
 	return nil
 }
@@ -112,17 +110,56 @@ func (p *Plugin) Stop(ctx context.Context) error {
 
 		if err := p.monitor.StopWithTimeout(timeout); err != nil {
 			p.logger.Error("Failed to stop monitor:", err)
-			p.updateStatus(plugGo.StatusError, err)
 			return fmt.Errorf("failed to stop monitor: %w", err)
 		}
 		p.monitor = nil
 	}
 
-	p.updateStatus(plugGo.StatusStopped, nil)
+	// Deliver what is still queued within the stop deadline
+	if p.dispatcher != nil {
+		if err := p.dispatcher.Close(ctx); err != nil {
+			p.logger.Warn("Failed to deliver all notifications:", err)
+		}
+		p.dispatcher = nil
+	}
+
+	// Persistent state is on disk, release the file; in-memory state is kept for the next start
+	if p.cfg.State.Backend != "" && p.cfg.State.Backend != store.BackendMemory {
+		if err := p.closeStore(); err != nil {
+			p.logger.Warn("Failed to close seen store:", err)
+		}
+	}
+
 	p.logger.Info("Plugin stopped")
 	return nil
 }
 
+// Drain delivers the queued notifications before the plugin is stopped
+// (implements plugGo.Drainer). Polling continues until Stop.
+func (p *Plugin) Drain(ctx context.Context) error {
+	p.mu.RLock()
+	dispatcher := p.dispatcher
+	p.mu.RUnlock()
+
+	if dispatcher == nil {
+		return nil
+	}
+	p.logger.Info("Draining queued notifications...")
+	return dispatcher.Flush(ctx)
+}
+
+// Health returns the polling health of each configured source
+// (implements plugGo.HealthReporter).
+func (p *Plugin) Health() []plugGo.ComponentHealth {
+	p.mu.RLock()
+	names := make([]string, 0, len(p.cfg.Sources))
+	for _, source := range p.cfg.Sources {
+		names = append(names, source.Name)
+	}
+	p.mu.RUnlock()
+	return p.health.report(names)
+}
+
 // GetLogger returns the logger.
 func (p *Plugin) GetLogger() plugGo.Logger {
 	return p.logger
@@ -150,7 +187,6 @@ func (p *Plugin) Reload(newConfig interface{}) error {
 	cfg, ok := newConfig.(*config.Config)
 	if !ok {
 		err := fmt.Errorf("invalid config type: expected *config.Config, got %T", newConfig)
-		p.updateStatus(plugGo.StatusError, err)
 		return err
 	}
 
@@ -162,39 +198,192 @@ func (p *Plugin) Reload(newConfig interface{}) error {
 		p.logger.Info("Stopping monitor for config reload")
 		if err := p.monitor.Stop(); err != nil {
 			p.logger.Error("Failed to stop monitor during reload:", err)
-			p.updateStatus(plugGo.StatusError, err)
 			return fmt.Errorf("failed to stop monitor: %w", err)
 		}
 	}
 
 	// Save old config for rollback
 	oldCfg := p.cfg
-	oldStatus := p.status
 	p.cfg = cfg
 
-	// If was running and new config enables plugin, restart Monitor with new config
-	if isRunning && p.cfg.Enabled {
+	// Reopen the seen store if its config changed
+	if !reflect.DeepEqual(oldCfg.State, cfg.State) {
+		if err := p.closeStore(); err != nil {
+			p.logger.Warn("Failed to close seen store:", err)
+		}
+	}
+	// Replace the notifiers if their config changed
+	if !reflect.DeepEqual(oldCfg.Notifications, cfg.Notifications) || oldCfg.DeadLetter != cfg.DeadLetter {
+		p.retireDispatcher()
+	}
+
+	// If was running, restart Monitor with new config
+	if isRunning {
 		p.logger.Info("Restarting monitor with new configuration")
-		p.monitor = NewMonitor(p.cfg, p.logger)
-		if err := p.monitor.Start(); err != nil {
+		err := p.openStore()
+		if err == nil {
+			err = p.openDispatcher()
+		}
+		if err == nil {
+			p.monitor = p.newMonitor()
+			err = p.monitor.Start()
+		}
+		if err != nil {
 			p.logger.Error("Failed to restart monitor:", err)
 			// Try to restore old config and restart
+			_ = p.closeStore()
+			p.retireDispatcher()
 			p.cfg = oldCfg
-			p.monitor = NewMonitor(p.cfg, p.logger)
-			_ = p.monitor.Start()
-			p.updateStatus(plugGo.StatusError, err)
+			if p.openStore() == nil && p.openDispatcher() == nil {
+				p.monitor = p.newMonitor()
+				_ = p.monitor.Start()
+			} else {
+				p.monitor = nil
+			}
 			return fmt.Errorf("failed to restart monitor: %w", err)
 		}
-		// Restore running status if was running before
-		p.updateStatus(plugGo.StatusRunning, nil)
 	} else {
 		p.monitor = nil
-		// Update status based on previous state
-		if oldStatus == plugGo.StatusRunning {
-			p.updateStatus(plugGo.StatusStopped, nil)
-		}
+		p.retireDispatcher()
 	}
 
 	p.logger.Info("Configuration reloaded successfully")
 	return nil
 }
+
+// ReloadWithDiff applies only what changed.
+// Hot changes (filters, notifications, log level) are swapped into the running monitor;
+// anything else falls back to a full Reload that restarts the source pollers.
+func (p *Plugin) ReloadWithDiff(newConfig interface{}, diff plugGoConfig.Diff) error {
+	cfg, ok := newConfig.(*config.Config)
+	if !ok {
+		return fmt.Errorf("invalid config type: expected *config.Config, got %T", newConfig)
+	}
+
+	p.mu.Lock()
+	if diff.RequiresRestart() || p.monitor == nil {
+		p.mu.Unlock()
+		return p.Reload(cfg)
+	}
+	defer p.mu.Unlock()
+
+	if err := p.monitor.SetConfig(cfg); err != nil {
+		return fmt.Errorf("failed to apply config: %w", err)
+	}
+	if diff.Changed("notifications") || diff.Changed("deadLetter") {
+		dispatcher, err := NewDispatcher(cfg, p.logger, p.notifyCh)
+		if err != nil {
+			_ = p.monitor.SetConfig(p.cfg)
+			return fmt.Errorf("failed to create notifiers: %w", err)
+		}
+		p.retireDispatcher()
+		p.dispatcher = dispatcher
+		p.monitor.SetDispatcher(dispatcher)
+	}
+
+	p.cfg = cfg
+	if diff.Changed("logLevel") {
+		if logger, ok := p.logger.(*plugGo.StandardLogger); ok {
+			logger.SetLevel(plugGo.ParseLogLevel(cfg.LogLevel))
+		}
+	}
+
+	p.logger.Info(fmt.Sprintf("Configuration hot-reloaded: %v", diff.Paths()))
+	return nil
+}
+
+// newMonitor creates a monitor for the current config sharing the plugin's fetcher and seen store.
+// Note: caller must hold the lock (p.mu).
+func (p *Plugin) newMonitor() *Monitor {
+	return NewMonitor(p.cfg, p.logger,
+		WithFetcher(p.fetcher),
+		WithSeenStore(p.seen, p.id, p.cfg.State.BaselineEnabled()),
+		WithDispatcher(p.dispatcher),
+		withHealth(p.health),
+	)
+}
+
+// openDispatcher creates the notification dispatcher for the current config if there is none.
+// Note: caller must hold the lock (p.mu).
+func (p *Plugin) openDispatcher() error {
+	if p.dispatcher != nil {
+		return nil
+	}
+	dispatcher, err := NewDispatcher(p.cfg, p.logger, p.notifyCh)
+	if err != nil {
+		return err
+	}
+	p.dispatcher = dispatcher
+	return nil
+}
+
+// retireDispatcher detaches the dispatcher and lets it deliver its queue in the background.
+// Note: caller must hold the lock (p.mu).
+func (p *Plugin) retireDispatcher() {
+	if p.dispatcher == nil {
+		return
+	}
+	old := p.dispatcher
+	p.dispatcher = nil
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), dispatcherDrainTimeout)
+		defer cancel()
+		if err := old.Close(ctx); err != nil {
+			p.logger.Warn("Failed to deliver all notifications of previous config:", err)
+		}
+	}()
+}
+
+// openStore opens the seen store for the current config if it isn't open yet.
+// Note: caller must hold the lock (p.mu).
+func (p *Plugin) openStore() error {
+	if p.seen != nil {
+		return nil
+	}
+
+	state := p.cfg.State
+	seen, err := store.Open(store.Options{
+		Backend: state.Backend,
+		Path:    state.Path,
+		Retention: store.Retention{
+			MaxItems: state.MaxItems,
+			MaxAge:   state.MaxAge,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open seen store: %w", err)
+	}
+	p.seen = seen
+	return nil
+}
+
+// closeStore closes the seen store.
+// Note: caller must hold the lock (p.mu).
+func (p *Plugin) closeStore() error {
+	if p.seen == nil {
+		return nil
+	}
+	err := p.seen.Close()
+	p.seen = nil
+	return err
+}
+
+// DryRunFilters shows which of the most recently fetched announcements the filters of cfg
+// would pass, without handling them. With a nil cfg the current config is used, so a
+// candidate config can be checked before it is hot-reloaded.
+func (p *Plugin) DryRunFilters(cfg *config.Config) ([]FilterMatch, error) {
+	p.mu.RLock()
+	if cfg == nil {
+		cfg = p.cfg
+	}
+	p.mu.RUnlock()
+
+	var items []Announcement
+	for _, source := range cfg.Sources {
+		for _, item := range p.fetcher.Recent(source.URL) {
+			item.Source = source.Name
+			items = append(items, item)
+		}
+	}
+	return DryRunFilters(cfg, items)
+}
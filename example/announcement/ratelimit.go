@@ -0,0 +1,123 @@
+package announcement
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// hostLimiters spaces out requests to the same host across all monitors of the process,
+// so several instances or sources polling one server don't hit it at once.
+var hostLimiters = struct {
+	hosts map[string]*hostLimiter
+	mu    sync.Mutex
+}{hosts: make(map[string]*hostLimiter)}
+
+// hostLimiter hands out request slots for one host.
+type hostLimiter struct {
+	intervals map[hostSource]time.Duration // Interval wanted by each live source of the host
+	last      time.Time                    // Time of the last slot handed out
+}
+
+// hostSource identifies a source polled by a monitor.
+type hostSource struct {
+	monitor *Monitor
+	source  string
+}
+
+// interval returns the largest interval wanted by the live sources of the host.
+// Note: caller must hold hostLimiters.mu.
+func (h *hostLimiter) interval() time.Duration {
+	var interval time.Duration
+	for _, v := range h.intervals {
+		if v > interval {
+			interval = v
+		}
+	}
+	return interval
+}
+
+// hostOf returns the host of rawURL, or "" if it has none.
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "" // The fetch reports the invalid URL
+	}
+	return u.Host
+}
+
+// addHostSource registers the rate limit a source wants for its host, until removeHostSource.
+func addHostSource(key hostSource, rawURL string, interval time.Duration) {
+	host := hostOf(rawURL)
+	if host == "" || interval <= 0 {
+		return
+	}
+
+	hostLimiters.mu.Lock()
+	defer hostLimiters.mu.Unlock()
+	h, ok := hostLimiters.hosts[host]
+	if !ok {
+		h = &hostLimiter{intervals: make(map[hostSource]time.Duration)}
+		hostLimiters.hosts[host] = h
+	}
+	h.intervals[key] = interval
+}
+
+// removeHostSource drops the rate limit of a source, e.g. once its monitor stopped,
+// so the host is spaced by the sources still polling it.
+func removeHostSource(key hostSource, rawURL string) {
+	host := hostOf(rawURL)
+
+	hostLimiters.mu.Lock()
+	defer hostLimiters.mu.Unlock()
+	h, ok := hostLimiters.hosts[host]
+	if !ok {
+		return
+	}
+	delete(h.intervals, key)
+	if len(h.intervals) == 0 {
+		delete(hostLimiters.hosts, host)
+	}
+}
+
+// waitHost blocks until a request to the host of rawURL may be sent.
+// Sources sharing a host are all spaced by the largest interval any live source
+// of the host registered with addHostSource. A wait cancelled by ctx gives its
+// slot back if no later one was handed out.
+func waitHost(ctx context.Context, rawURL string) error {
+	host := hostOf(rawURL)
+
+	hostLimiters.mu.Lock()
+	h, ok := hostLimiters.hosts[host]
+	if !ok {
+		hostLimiters.mu.Unlock()
+		return nil
+	}
+	now := time.Now()
+	prev := h.last
+	slot := h.last.Add(h.interval())
+	if slot.Before(now) {
+		slot = now
+	}
+	h.last = slot
+	hostLimiters.mu.Unlock()
+
+	wait := time.Until(slot)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		hostLimiters.mu.Lock()
+		if h.last.Equal(slot) {
+			h.last = prev
+		}
+		hostLimiters.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,47 @@
+package announcement
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestHostIntervalFollowsLiveSources(t *testing.T) {
+	const url = "https://ratelimit.example/feed"
+	slow := hostSource{source: "slow"}
+	fast := hostSource{source: "fast"}
+	addHostSource(slow, url, time.Hour)
+	addHostSource(fast, url, 50*time.Millisecond)
+	defer removeHostSource(fast, url)
+
+	// Both live: the hour of the slow source applies
+	if err := waitHost(context.Background(), url); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := waitHost(ctx, url); err == nil {
+		t.Fatal("second request not spaced by the slow source's interval")
+	}
+
+	// Once the slow source is gone, the fast interval applies again
+	removeHostSource(slow, url)
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if err := waitHost(context.Background(), url); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > time.Second {
+		t.Errorf("two requests took %v, want about 50ms", elapsed)
+	}
+
+	// Without live sources the host is no longer tracked
+	removeHostSource(fast, url)
+	hostLimiters.mu.Lock()
+	_, tracked := hostLimiters.hosts["ratelimit.example"]
+	hostLimiters.mu.Unlock()
+	if tracked {
+		t.Error("host still tracked without sources")
+	}
+}
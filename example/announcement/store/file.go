@@ -0,0 +1,122 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// fileFormatVersion is the version of the JSON file layout.
+const fileFormatVersion = 1
+
+// fileDocument is the JSON file layout.
+type fileDocument struct {
+	Version int         `json:"version"`
+	Keys    []fileEntry `json:"keys"`
+}
+
+type fileEntry struct {
+	Instance string               `json:"instance"`
+	Source   string               `json:"source"`
+	Items    map[string]time.Time `json:"items"`
+}
+
+// FileStore keeps seen IDs in a JSON file, rewritten atomically on every change.
+// Suited to small numbers of sources; use the kv backend for larger state.
+type FileStore struct {
+	path  string
+	index *index
+	mu    sync.Mutex
+}
+
+// NewFileStore opens (or creates) a JSON file store.
+func NewFileStore(path string, retention Retention) (*FileStore, error) {
+	s := &FileStore{path: path, index: newIndex(retention)}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return s, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read store %s: %w", path, err)
+	}
+
+	var doc fileDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse store %s: %w", path, err)
+	}
+	for _, entry := range doc.Keys {
+		key := Key{Instance: entry.Instance, Source: entry.Source}
+		s.index.keys[key] = make(map[string]time.Time, len(entry.Items))
+		for id, t := range entry.Items {
+			s.index.keys[key][id] = t
+		}
+		s.index.prune(key, time.Now())
+	}
+	return s, nil
+}
+
+// Unseen implements Store.
+func (s *FileStore) Unseen(key Key, ids []string) ([]string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	unseen, known := s.index.unseen(key, ids)
+	return unseen, known, nil
+}
+
+// MarkSeen implements Store.
+func (s *FileStore) MarkSeen(key Key, ids []string, t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.index.add(key, ids, t)
+	return s.save()
+}
+
+// Close implements Store.
+func (s *FileStore) Close() error {
+	return nil
+}
+
+// save writes the whole index to a temp file and renames it over the store file.
+// Note: caller must hold the lock (s.mu).
+func (s *FileStore) save() error {
+	doc := fileDocument{Version: fileFormatVersion}
+	for key, items := range s.index.keys {
+		doc.Keys = append(doc.Keys, fileEntry{Instance: key.Instance, Source: key.Source, Items: items})
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, data)
+}
+
+// writeFileAtomic writes data to a temp file in the same directory and renames it to path.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
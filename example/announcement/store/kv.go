@@ -0,0 +1,231 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// compactThreshold is the minimum number of log records before compaction is considered.
+const compactThreshold = 1024
+
+// kvOp is the operation of a log record.
+type kvOp string
+
+const (
+	kvPut kvOp = "put" // Record an ID as seen (an empty ID only marks the key as known)
+	kvDel kvOp = "del" // Forget an ID evicted by retention
+)
+
+// kvRecord is one line of the append-only log.
+type kvRecord struct {
+	Op       kvOp      `json:"op"`
+	Instance string    `json:"i"`
+	Source   string    `json:"s"`
+	ID       string    `json:"id,omitempty"`
+	Time     time.Time `json:"t,omitempty"`
+}
+
+// KVStore is an embedded key-value store backed by an append-only log.
+// Every change appends a record instead of rewriting the whole state; the log is
+// replayed on open and compacted once it grows well beyond the live state.
+type KVStore struct {
+	path    string
+	index   *index
+	file    *os.File
+	records int // Records in the log file
+	mu      sync.Mutex
+}
+
+// NewKVStore opens (or creates) a key-value store at path.
+func NewKVStore(path string, retention Retention) (*KVStore, error) {
+	s := &KVStore{path: path, index: newIndex(retention)}
+
+	if err := s.replay(); err != nil {
+		return nil, err
+	}
+
+	// Drop anything that expired while the process was down
+	now := time.Now()
+	for key := range s.index.keys {
+		s.index.prune(key, now)
+	}
+
+	if err := s.compact(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Unseen implements Store.
+func (s *KVStore) Unseen(key Key, ids []string) ([]string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	unseen, known := s.index.unseen(key, ids)
+	return unseen, known, nil
+}
+
+// MarkSeen implements Store.
+func (s *KVStore) MarkSeen(key Key, ids []string, t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return errors.New("store is closed")
+	}
+
+	_, known := s.index.keys[key]
+	evicted := s.index.add(key, ids, t)
+
+	records := make([]kvRecord, 0, len(ids)+len(evicted)+1)
+	if !known && len(ids) == 0 {
+		records = append(records, kvRecord{Op: kvPut, Instance: key.Instance, Source: key.Source, Time: t})
+	}
+	for _, id := range ids {
+		records = append(records, kvRecord{Op: kvPut, Instance: key.Instance, Source: key.Source, ID: id, Time: t})
+	}
+	for _, id := range evicted {
+		records = append(records, kvRecord{Op: kvDel, Instance: key.Instance, Source: key.Source, ID: id})
+	}
+	if err := s.append(records); err != nil {
+		return err
+	}
+
+	if s.records > compactThreshold && s.records > 2*s.liveRecords() {
+		return s.compact()
+	}
+	return nil
+}
+
+// Close implements Store.
+func (s *KVStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// replay rebuilds the index from the log.
+// A truncated last line (crash during append) is ignored.
+func (s *KVStore) replay() error {
+	f, err := os.Open(s.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to open store %s: %w", s.path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	for scanner.Scan() {
+		var rec kvRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			continue
+		}
+		key := Key{Instance: rec.Instance, Source: rec.Source}
+		set, ok := s.index.keys[key]
+		if !ok {
+			set = make(map[string]time.Time)
+			s.index.keys[key] = set
+		}
+		switch rec.Op {
+		case kvPut:
+			if rec.ID != "" {
+				set[rec.ID] = rec.Time
+			}
+		case kvDel:
+			delete(set, rec.ID)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read store %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// append writes records to the log and syncs it.
+// Note: caller must hold the lock (s.mu).
+func (s *KVStore) append(records []kvRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	w := bufio.NewWriter(s.file)
+	encoder := json.NewEncoder(w)
+	for _, rec := range records {
+		if err := encoder.Encode(rec); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	s.records += len(records)
+	return s.file.Sync()
+}
+
+// liveRecords returns the number of records needed to describe the current state.
+// Note: caller must hold the lock (s.mu).
+func (s *KVStore) liveRecords() int {
+	n := 0
+	for _, set := range s.index.keys {
+		n += len(set)
+		if len(set) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// compact rewrites the log with only the live state and reopens it for appending.
+// Note: caller must hold the lock (s.mu) or own the store exclusively.
+func (s *KVStore) compact() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+
+	var records []kvRecord
+	for key, set := range s.index.keys {
+		if len(set) == 0 {
+			records = append(records, kvRecord{Op: kvPut, Instance: key.Instance, Source: key.Source})
+		}
+		for id, t := range set {
+			records = append(records, kvRecord{Op: kvPut, Instance: key.Instance, Source: key.Source, ID: id, Time: t})
+		}
+	}
+
+	var buf []byte
+	for _, rec := range records {
+		line, err := json.Marshal(rec)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+	if err := writeFileAtomic(s.path, buf); err != nil {
+		return fmt.Errorf("failed to compact store %s: %w", s.path, err)
+	}
+
+	if s.file != nil {
+		s.file.Close()
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		s.file = nil
+		return fmt.Errorf("failed to open store %s: %w", s.path, err)
+	}
+	s.file = f
+	s.records = len(records)
+	return nil
+}
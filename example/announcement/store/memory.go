@@ -0,0 +1,39 @@
+package store
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps seen IDs in memory only.
+// State survives plugin reloads and restarts but not process restarts.
+type MemoryStore struct {
+	index *index
+	mu    sync.Mutex
+}
+
+// NewMemoryStore creates an in-memory store.
+func NewMemoryStore(retention Retention) *MemoryStore {
+	return &MemoryStore{index: newIndex(retention)}
+}
+
+// Unseen implements Store.
+func (s *MemoryStore) Unseen(key Key, ids []string) ([]string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	unseen, known := s.index.unseen(key, ids)
+	return unseen, known, nil
+}
+
+// MarkSeen implements Store.
+func (s *MemoryStore) MarkSeen(key Key, ids []string, t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.index.add(key, ids, t)
+	return nil
+}
+
+// Close implements Store.
+func (s *MemoryStore) Close() error {
+	return nil
+}
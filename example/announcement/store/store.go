@@ -0,0 +1,213 @@
+package store
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Backend names accepted by Open.
+const (
+	BackendMemory = "memory"
+	BackendFile   = "file"
+	BackendKV     = "kv"
+)
+
+// DefaultMaxItems is the number of seen IDs kept per key when no limit is configured.
+const DefaultMaxItems = 1000
+
+// Key identifies the seen-item set of one source of one plugin instance.
+type Key struct {
+	Instance string
+	Source   string
+}
+
+// String returns string representation.
+func (k Key) String() string {
+	return k.Instance + "/" + k.Source
+}
+
+// Retention limits how many seen IDs are kept per key.
+type Retention struct {
+	MaxItems int           // Keep at most this many IDs per key, oldest evicted first (DefaultMaxItems if 0)
+	MaxAge   time.Duration // Drop IDs older than this (0 keeps them until MaxItems evicts them)
+}
+
+// Store remembers which announcement IDs have already been seen.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Unseen returns the IDs not seen before for the key, in input order,
+	// and whether the key had any recorded state (false on first run).
+	Unseen(key Key, ids []string) (unseen []string, known bool, err error)
+	// MarkSeen records IDs as seen at time t and applies retention.
+	// Marking with no IDs still records the key as known.
+	MarkSeen(key Key, ids []string, t time.Time) error
+	// Close releases the store.
+	Close() error
+}
+
+// Options configures Open.
+type Options struct {
+	Backend   string // memory (default), file or kv
+	Path      string // File path for file and kv backends
+	Retention Retention
+}
+
+// shared keeps file-backed stores open once per path, so instances configured
+// with the same path share one store instead of overwriting each other.
+var shared = struct {
+	stores map[string]*sharedStore
+	mu     sync.Mutex
+}{stores: make(map[string]*sharedStore)}
+
+// sharedStore is a reference-counted file-backed store.
+type sharedStore struct {
+	Store
+	path string
+	refs int
+}
+
+// handle is one user's reference to a shared store.
+type handle struct {
+	*sharedStore
+	once sync.Once
+}
+
+// Close releases this reference, closing the store after the last one.
+func (h *handle) Close() error {
+	var err error
+	h.once.Do(func() {
+		shared.mu.Lock()
+		defer shared.mu.Unlock()
+		h.refs--
+		if h.refs == 0 {
+			delete(shared.stores, h.path)
+			err = h.Store.Close()
+		}
+	})
+	return err
+}
+
+// Open opens a store for the given options.
+// Memory stores are private; file and kv stores are shared per path.
+func Open(opts Options) (Store, error) {
+	switch opts.Backend {
+	case "", BackendMemory:
+		return NewMemoryStore(opts.Retention), nil
+	case BackendFile, BackendKV:
+	default:
+		return nil, fmt.Errorf("unknown store backend: %q", opts.Backend)
+	}
+
+	if opts.Path == "" {
+		return nil, fmt.Errorf("store backend %s requires a path", opts.Backend)
+	}
+	path, err := filepath.Abs(opts.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+
+	if s, ok := shared.stores[path]; ok {
+		s.refs++
+		return &handle{sharedStore: s}, nil
+	}
+
+	var s Store
+	if opts.Backend == BackendFile {
+		s, err = NewFileStore(path, opts.Retention)
+	} else {
+		s, err = NewKVStore(path, opts.Retention)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ss := &sharedStore{Store: s, path: path, refs: 1}
+	shared.stores[path] = ss
+	return &handle{sharedStore: ss}, nil
+}
+
+// index is the in-memory seen-ID index shared by all backends.
+type index struct {
+	keys      map[Key]map[string]time.Time
+	retention Retention
+}
+
+func newIndex(retention Retention) *index {
+	if retention.MaxItems <= 0 {
+		retention.MaxItems = DefaultMaxItems
+	}
+	return &index{
+		keys:      make(map[Key]map[string]time.Time),
+		retention: retention,
+	}
+}
+
+// unseen returns the IDs not in the key's set and whether the key is known.
+func (x *index) unseen(key Key, ids []string) ([]string, bool) {
+	set, known := x.keys[key]
+	var result []string
+	dup := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if dup[id] {
+			continue
+		}
+		dup[id] = true
+		if _, ok := set[id]; !ok {
+			result = append(result, id)
+		}
+	}
+	return result, known
+}
+
+// add records IDs and returns the IDs evicted by retention.
+func (x *index) add(key Key, ids []string, t time.Time) []string {
+	set, ok := x.keys[key]
+	if !ok {
+		set = make(map[string]time.Time)
+		x.keys[key] = set
+	}
+	for _, id := range ids {
+		set[id] = t
+	}
+	return x.prune(key, t)
+}
+
+// prune applies retention to a key and returns the evicted IDs.
+func (x *index) prune(key Key, now time.Time) []string {
+	set := x.keys[key]
+	var evicted []string
+
+	if x.retention.MaxAge > 0 {
+		cutoff := now.Add(-x.retention.MaxAge)
+		for id, seen := range set {
+			if seen.Before(cutoff) {
+				delete(set, id)
+				evicted = append(evicted, id)
+			}
+		}
+	}
+
+	if over := len(set) - x.retention.MaxItems; over > 0 {
+		ids := make([]string, 0, len(set))
+		for id := range set {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool {
+			if set[ids[i]].Equal(set[ids[j]]) {
+				return ids[i] < ids[j]
+			}
+			return set[ids[i]].Before(set[ids[j]])
+		})
+		for _, id := range ids[:over] {
+			delete(set, id)
+			evicted = append(evicted, id)
+		}
+	}
+	return evicted
+}
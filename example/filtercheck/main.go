@@ -0,0 +1,122 @@
+// Command filtercheck shows which announcements the filters of a boot.yaml would pass,
+// without notifying anything. Use it to try filter changes before hot-reloading them.
+//
+//	go run ./example/filtercheck -config example/host/boot.yaml -instance official
+//	go run ./example/filtercheck -config boot.yaml -items recent.json   # items from a file instead of fetching
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	plugGoConfig "github.com/seencxy/plugGo/config"
+	"github.com/seencxy/plugGo/example/announcement"
+	announcementConfig "github.com/seencxy/plugGo/example/announcement/config"
+)
+
+func main() {
+	configPath := flag.String("config", "boot.yaml", "boot.yaml to read announcement instances from")
+	instance := flag.String("instance", "", "only check this instance (default: all)")
+	itemsPath := flag.String("items", "", "JSON array of announcements to check instead of fetching the sources")
+	showAll := flag.Bool("all", false, "also list announcements the filters reject")
+	flag.Parse()
+
+	if err := run(*configPath, *instance, *itemsPath, *showAll); err != nil {
+		fmt.Fprintln(os.Stderr, "filtercheck:", err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath, instance, itemsPath string, showAll bool) error {
+	raw, err := os.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+	sections, err := plugGoConfig.GetYAMLSectionItems(raw, announcement.PluginName)
+	if err != nil {
+		return err
+	}
+
+	var fileItems []announcement.Announcement
+	if itemsPath != "" {
+		data, err := os.ReadFile(itemsPath)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &fileItems); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", itemsPath, err)
+		}
+	}
+
+	factory := announcement.NewFactory()
+	fetcher := announcement.NewFetcher(nil)
+	for i, section := range sections {
+		cfg := factory.DefaultConfig().(*announcementConfig.Config)
+		if err := plugGoConfig.UnmarshalYAML(section, cfg); err != nil {
+			return fmt.Errorf("%s[%d]: %w", announcement.PluginName, i, err)
+		}
+		if cfg.Name == "" {
+			cfg.Name = fmt.Sprintf("%s-%d", announcement.PluginName, i)
+		}
+		if instance != "" && cfg.Name != instance {
+			continue
+		}
+		if err := factory.ValidateConfig(cfg); err != nil {
+			return fmt.Errorf("%s: %w", cfg.Name, err)
+		}
+
+		items := fileItems
+		if itemsPath == "" {
+			items = fetchAll(fetcher, cfg)
+		}
+
+		matches, err := announcement.DryRunFilters(cfg, items)
+		if err != nil {
+			return fmt.Errorf("%s: %w", cfg.Name, err)
+		}
+		printMatches(cfg.Name, matches, showAll)
+	}
+	return nil
+}
+
+// fetchAll fetches every source of an instance, reporting failures on stderr.
+func fetchAll(fetcher *announcement.Fetcher, cfg *announcementConfig.Config) []announcement.Announcement {
+	var items []announcement.Announcement
+	for _, source := range cfg.Sources {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		fetched, _, err := fetcher.Fetch(ctx, source)
+		cancel()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: failed to fetch %s: %v\n", cfg.Name, source.Name, err)
+			continue
+		}
+		items = append(items, fetched...)
+	}
+	return items
+}
+
+func printMatches(name string, matches []announcement.FilterMatch, showAll bool) {
+	passed := 0
+	for _, m := range matches {
+		if m.Matched {
+			passed++
+		}
+	}
+	fmt.Printf("=== %s: %d of %d announcements pass ===\n", name, passed, len(matches))
+
+	for _, m := range matches {
+		if !m.Matched && !showAll {
+			continue
+		}
+		mark := "PASS"
+		if !m.Matched {
+			mark = "DROP"
+		}
+		fmt.Printf("%s [%s] %s\n     %s\n", mark, m.Item.Source, m.Item.Title, m.Reason)
+	}
+	fmt.Println()
+}
@@ -3,14 +3,21 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/seencxy/plugGo"
+	"github.com/seencxy/plugGo/cli"
 
-	// Import all plugins (triggers init to auto-register Entry registration functions)
+	// Import all plugins (triggers init to auto-register plugin factories)
 	_ "github.com/seencxy/plugGo/example/announcement"
 )
 
 func main() {
+	// Handle `host config validate|print boot.yaml` with the plugins imported above
+	if handled, err := cli.RunCLI(os.Args[1:]); handled {
+		os.Exit(cli.ExitCode(err))
+	}
+
 	fmt.Println("=== PlugGo Framework Example - Multi-Instance Demo ===")
 	fmt.Println()
 
@@ -19,12 +26,12 @@ func main() {
 	boot := plugGo.NewBoot()
 
 	// Optional: add before bootstrap hook
-	boot.AddHookFuncBeforeBootstrap("AnnouncementEntry", "official", func(ctx context.Context) {
+	boot.AddHookFuncBeforeBootstrap("announcement", "official", func(ctx context.Context) {
 		fmt.Println("[Hook] Before bootstrapping official announcement entry")
 	})
 
 	// Optional: add after bootstrap hook
-	boot.AddHookFuncAfterBootstrap("AnnouncementEntry", "official", func(ctx context.Context) {
+	boot.AddHookFuncAfterBootstrap("announcement", "official", func(ctx context.Context) {
 		fmt.Println("[Hook] After bootstrapping official announcement entry")
 	})
 
@@ -35,7 +42,10 @@ func main() {
 
 	// Bootstrap all Entries
 	fmt.Println("Bootstrapping all entries...")
-	boot.Bootstrap(context.Background())
+	if err := boot.Bootstrap(context.Background()); err != nil {
+		// Entries that were stuck or cancelled are listed in the error
+		fmt.Println("Bootstrap incomplete:", err)
+	}
 
 	// ===== Multi-instance demo =====
 	fmt.Println()
@@ -45,14 +55,14 @@ func main() {
 	fmt.Printf("Total entries: %d\n", boot.CountEntries())
 
 	// Get all instances of specified type
-	announcementEntries := boot.GetEntriesByType("AnnouncementEntry")
-	fmt.Printf("AnnouncementEntry instances: %d\n", len(announcementEntries))
+	announcementEntries := boot.GetEntriesByType("announcement")
+	fmt.Printf("announcement instances: %d\n", len(announcementEntries))
 	for name, entry := range announcementEntries {
 		fmt.Printf("  - %s: %s\n", name, entry.GetDescription())
 	}
 
 	// Get single instance
-	if official := boot.GetEntry("AnnouncementEntry", "official"); official != nil {
+	if official := boot.GetEntry("announcement", "official"); official != nil {
 		fmt.Printf("\nDirect access to 'official': %s\n", official.String())
 	}
 
@@ -63,13 +73,18 @@ func main() {
 	fmt.Println("=================================")
 	fmt.Println()
 
-	// Note: To monitor plugin status, you can access the plugin instance
-	// through your specific Entry implementation and call Status() or
-	// subscribe to StatusNotify() channel. See template/entry.go for example.
+	// Note: Entries built from factories are registry instances too, so status
+	// can be monitored with registry.Subscribe("official", 0), which gives every
+	// consumer its own event stream. See example/multi_instance for an example.
 
 	// Wait for shutdown signal and gracefully exit
-	boot.WaitForShutdownSig(context.Background())
+	report := boot.WaitForShutdownSig(context.Background())
 
 	fmt.Println()
+	if report.ExitCode != plugGo.ShutdownExitOK {
+		// Entries or hooks that failed or were abandoned are listed in the report
+		fmt.Println(report)
+		os.Exit(report.ExitCode)
+	}
 	fmt.Println("All entries stopped. Goodbye!")
 }
@@ -34,7 +34,6 @@ func main() {
 	fmt.Println("Creating instance 1: GitHub Monitor")
 	githubConfig := &announcementConfig.Config{
 		Name:     "github-monitor",
-		Enabled:  true,
 		LogLevel: "info",
 		Sources: []announcementConfig.Source{
 			{
@@ -56,6 +55,15 @@ func main() {
 		return
 	}
 	fmt.Printf("  [OK] Instance created: %s\n", instance1.ID())
+
+	// Subscribe to status events (current status is replayed first)
+	statusSub := instance1.Subscribe(0)
+	go func() {
+		for event := range statusSub.Events() {
+			fmt.Printf("  [STATUS] %s: %s (err=%v)\n", instance1.ID(), event.Status, event.Error)
+		}
+	}()
+
 	if err := instance1.Start(context.Background()); err != nil {
 		fmt.Printf("  [FAIL] Start failed: %v\n", err)
 		return
@@ -67,7 +75,6 @@ func main() {
 	fmt.Println("Creating instance 2: Tech Blog Monitor")
 	blogConfig := &announcementConfig.Config{
 		Name:     "blog-monitor",
-		Enabled:  true,
 		LogLevel: "debug",
 		Sources: []announcementConfig.Source{
 			{
@@ -144,7 +151,6 @@ func main() {
 	fmt.Println("Updating instance 1 config...")
 	newGithubConfig := &announcementConfig.Config{
 		Name:     "github-monitor",
-		Enabled:  true,
 		LogLevel: "debug",
 		Sources: []announcementConfig.Source{
 			{
@@ -186,15 +192,10 @@ func main() {
 	fmt.Println("\n=== Shutting Down All Instances ===")
 	for _, inst := range allInstances {
 		fmt.Printf("Stopping instance: %s\n", inst.ID())
-		if err := inst.Stop(context.Background()); err != nil {
+		if err := registry.StopAndRemove(context.Background(), inst.ID()); err != nil {
 			fmt.Printf("  [FAIL] Stop failed: %v\n", err)
 		} else {
-			fmt.Println("  [OK] Stopped")
-		}
-
-		// Remove from registry
-		if err := registry.RemoveInstance(inst.ID()); err != nil {
-			fmt.Printf("  [FAIL] Remove failed: %v\n", err)
+			fmt.Println("  [OK] Stopped and removed")
 		}
 	}
 
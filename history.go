@@ -0,0 +1,137 @@
+package plugGo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/seencxy/plugGo/config"
+)
+
+// DefaultHistorySize is the number of lifecycle events kept per instance.
+const DefaultHistorySize = 100
+
+// HistoryEventKind is the kind of a lifecycle history event.
+type HistoryEventKind string
+
+const (
+	// HistoryStart records a Start operation.
+	HistoryStart HistoryEventKind = "start"
+	// HistoryStop records a Stop operation.
+	HistoryStop HistoryEventKind = "stop"
+	// HistoryReload records a config reload.
+	HistoryReload HistoryEventKind = "reload"
+	// HistoryRestart records a Restart operation.
+	HistoryRestart HistoryEventKind = "restart"
+	// HistoryRollback records a config rollback after a failed transaction.
+	HistoryRollback HistoryEventKind = "rollback"
+	// HistoryStatusChange records a lifecycle status transition.
+	HistoryStatusChange HistoryEventKind = "status_change"
+	// HistoryError records a failure that moved the instance to Error.
+	HistoryError HistoryEventKind = "error"
+)
+
+// HistoryEvent is a single entry of an instance's lifecycle history.
+type HistoryEvent struct {
+	Time          time.Time        `json:"time"`
+	InstanceID    string           `json:"instanceId"`
+	PluginType    string           `json:"pluginType"`
+	Kind          HistoryEventKind `json:"kind"`
+	Actor         string           `json:"actor,omitempty"`         // Who triggered the operation (see WithActor)
+	From          PluginStatus     `json:"from"`                    // Status before the event
+	To            PluginStatus     `json:"to"`                      // Status after the event
+	Message       string           `json:"message,omitempty"`       // Reason or outcome description
+	Error         string           `json:"error,omitempty"`         // Error message if the operation failed
+	ConfigChanges []string         `json:"configChanges,omitempty"` // Changed config fields (reload and rollback only)
+}
+
+// actorKey is the context key for the operation actor.
+type actorKey struct{}
+
+// WithActor returns a context recording who triggers lifecycle operations.
+// The actor is written to history and audit records, e.g. "admin:alice" or "signal:SIGHUP".
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored by WithActor, or "" if none.
+func ActorFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// historyRing is a bounded ring buffer of history events.
+type historyRing struct {
+	events []HistoryEvent
+	next   int
+	full   bool
+	mu     sync.RWMutex
+}
+
+func newHistoryRing(size int) *historyRing {
+	if size <= 0 {
+		size = DefaultHistorySize
+	}
+	return &historyRing{events: make([]HistoryEvent, size)}
+}
+
+// add appends an event, overwriting the oldest one when full.
+func (r *historyRing) add(event HistoryEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events[r.next] = event
+	r.next = (r.next + 1) % len(r.events)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// snapshot returns the events from oldest to newest.
+func (r *historyRing) snapshot() []HistoryEvent {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.snapshotLocked()
+}
+
+// snapshotLocked is snapshot without locking.
+// Note: caller must hold the lock (r.mu).
+func (r *historyRing) snapshotLocked() []HistoryEvent {
+	if !r.full {
+		return append([]HistoryEvent(nil), r.events[:r.next]...)
+	}
+	result := make([]HistoryEvent, 0, len(r.events))
+	result = append(result, r.events[r.next:]...)
+	return append(result, r.events[:r.next]...)
+}
+
+// resize changes the capacity, keeping the newest events.
+func (r *historyRing) resize(size int) {
+	if size <= 0 {
+		size = DefaultHistorySize
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	events := r.snapshotLocked()
+	if len(events) > size {
+		events = events[len(events)-size:]
+	}
+	r.events = make([]HistoryEvent, size)
+	copy(r.events, events)
+	r.next = len(events) % size
+	r.full = len(events) == size
+}
+
+// configChangeSummary returns the paths of config values that differ.
+func configChangeSummary(oldConfig, newConfig interface{}) []string {
+	diff, err := config.Compare(oldConfig, newConfig)
+	if err != nil {
+		return []string{"."}
+	}
+	return diff.Paths()
+}
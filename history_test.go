@@ -0,0 +1,154 @@
+package plugGo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHistoryRingWraparound(t *testing.T) {
+	ring := newHistoryRing(3)
+	if got := ring.snapshot(); len(got) != 0 {
+		t.Fatalf("snapshot of empty ring = %v", got)
+	}
+
+	for i := 1; i <= 5; i++ {
+		ring.add(HistoryEvent{Message: fmt.Sprint(i)})
+		want := []string{}
+		for j := max(1, i-2); j <= i; j++ {
+			want = append(want, fmt.Sprint(j))
+		}
+		if got := historyMessages(ring.snapshot()); !equalStrings(got, want) {
+			t.Fatalf("after %d adds: %v, want %v", i, got, want)
+		}
+	}
+
+	// Growing keeps every event, shrinking keeps the newest ones
+	ring.resize(5)
+	if got := historyMessages(ring.snapshot()); !equalStrings(got, []string{"3", "4", "5"}) {
+		t.Errorf("after growing: %v", got)
+	}
+	ring.add(HistoryEvent{Message: "6"})
+	ring.add(HistoryEvent{Message: "7"})
+	ring.add(HistoryEvent{Message: "8"})
+	if got := historyMessages(ring.snapshot()); !equalStrings(got, []string{"4", "5", "6", "7", "8"}) {
+		t.Errorf("after wrapping the grown ring: %v", got)
+	}
+	ring.resize(2)
+	if got := historyMessages(ring.snapshot()); !equalStrings(got, []string{"7", "8"}) {
+		t.Errorf("after shrinking: %v", got)
+	}
+	ring.add(HistoryEvent{Message: "9"})
+	if got := historyMessages(ring.snapshot()); !equalStrings(got, []string{"8", "9"}) {
+		t.Errorf("after wrapping the shrunk ring: %v", got)
+	}
+}
+
+func TestInstanceHistory(t *testing.T) {
+	instance, _ := newStubInstance("history")
+	ctx := WithActor(context.Background(), "admin:alice")
+	if err := instance.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := instance.UpdateConfigWithContext(ctx, &stubConfig{Value: "v2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var kinds []string
+	for _, event := range instance.History() {
+		kinds = append(kinds, fmt.Sprintf("%s %s->%s", event.Kind, event.From, event.To))
+		if event.InstanceID != "history" || event.PluginType != "stub" || event.Time.IsZero() {
+			t.Errorf("event not stamped: %+v", event)
+		}
+	}
+	want := []string{
+		"status_change Idle->Starting",
+		"status_change Starting->Running",
+		"start Idle->Running",
+		"status_change Running->Reloading",
+		"status_change Reloading->Running",
+		"reload Running->Running",
+	}
+	if !equalStrings(kinds, want) {
+		t.Errorf("history = %v, want %v", kinds, want)
+	}
+
+	events := instance.History()
+	if reload := events[len(events)-1]; reload.Actor != "admin:alice" || !equalStrings(reload.ConfigChanges, []string{"Value"}) {
+		t.Errorf("reload event = %+v", reload)
+	}
+}
+
+// blockingSink records events, blocking in Record until released.
+type blockingSink struct {
+	entered chan struct{} // Receives once per Record call
+	release chan struct{} // Closed to let Record calls return
+
+	mu     sync.Mutex
+	events []HistoryEvent
+}
+
+func (s *blockingSink) Record(event HistoryEvent) error {
+	s.entered <- struct{}{}
+	<-s.release
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func TestSlowAuditSinkDoesNotBlockInstance(t *testing.T) {
+	instance, _ := newStubInstance("slow-audit")
+	sink := &blockingSink{entered: make(chan struct{}, 100), release: make(chan struct{})}
+	instance.SetAuditSink(sink)
+	sub := instance.Subscribe(16)
+	defer instance.Unsubscribe(sub)
+	<-sub.Events() // Replay
+
+	started := make(chan error, 1)
+	go func() { started <- instance.Start(context.Background()) }()
+	<-sink.entered // Start is now blocked writing its first audit record
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = instance.Status()
+		_ = instance.GetConfig()
+		_ = instance.History()
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Status blocked while the audit sink was writing")
+	}
+	select {
+	case event := <-sub.Events():
+		if event.Status != StatusStarting {
+			t.Errorf("event = %s, want Starting", event.Status)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("subscriber not notified while the audit sink was writing")
+	}
+
+	close(sink.release)
+	if err := <-started; err != nil {
+		t.Fatal(err)
+	}
+
+	// Every event reaches the sink, in history order
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	if got, want := historyMessages(sink.events), historyMessages(instance.History()); !equalStrings(got, want) {
+		t.Errorf("audited %v, want %v", got, want)
+	}
+}
+
+func historyMessages(events []HistoryEvent) []string {
+	messages := make([]string, len(events))
+	for i, event := range events {
+		messages[i] = event.Message
+	}
+	return messages
+}
@@ -0,0 +1,357 @@
+package plugGo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+)
+
+// HookPhase is the lifecycle phase a hook runs in.
+type HookPhase int
+
+const (
+	// HookBeforeBootstrap runs before an Entry bootstraps; an error vetoes the bootstrap.
+	HookBeforeBootstrap HookPhase = iota
+	// HookAfterBootstrap runs after an Entry bootstrapped.
+	HookAfterBootstrap
+	// HookBeforeInterrupt runs before an Entry is interrupted; an error vetoes the interrupt,
+	// except during Shutdown where it is only logged.
+	HookBeforeInterrupt
+	// HookAfterInterrupt runs after an Entry was interrupted (HookEvent.Err reports a timeout).
+	HookAfterInterrupt
+	// HookBeforeReload runs before a plugin Entry reloads its config; an error vetoes the reload.
+	HookBeforeReload
+	// HookAfterReload runs after a plugin Entry reloaded (HookEvent.Err is the reload result).
+	HookAfterReload
+	// HookStatusChange runs, asynchronously, after each status transition of a bootstrapped plugin Entry.
+	HookStatusChange
+)
+
+// String returns the phase name.
+func (p HookPhase) String() string {
+	switch p {
+	case HookBeforeBootstrap:
+		return "BeforeBootstrap"
+	case HookAfterBootstrap:
+		return "AfterBootstrap"
+	case HookBeforeInterrupt:
+		return "BeforeInterrupt"
+	case HookAfterInterrupt:
+		return "AfterInterrupt"
+	case HookBeforeReload:
+		return "BeforeReload"
+	case HookAfterReload:
+		return "AfterReload"
+	case HookStatusChange:
+		return "StatusChange"
+	default:
+		return "Unknown"
+	}
+}
+
+// vetoes reports whether an error returned in this phase cancels the phase.
+func (p HookPhase) vetoes() bool {
+	return p == HookBeforeBootstrap || p == HookBeforeInterrupt || p == HookBeforeReload
+}
+
+// HookEvent describes the Entry and phase a hook is called for.
+type HookEvent struct {
+	Phase     HookPhase
+	EntryType string
+	EntryName string
+	Entry     Entry
+
+	// Status is the transition (HookStatusChange only).
+	Status StatusEvent
+	// OldConfig and NewConfig are the configs (reload phases only).
+	OldConfig interface{}
+	NewConfig interface{}
+	// Err is the outcome of the phase (after phases only).
+	Err error
+}
+
+// HookFunc is a lifecycle hook. In Before phases a returned error vetoes the phase;
+// in other phases it is logged.
+type HookFunc func(ctx context.Context, event HookEvent) error
+
+// HookOption is a hook configuration option function.
+type HookOption func(*hook)
+
+// WithHookPriority orders hooks of the same phase: lower priorities run first (default 0).
+// Hooks of equal priority run in registration order.
+func WithHookPriority(priority int) HookOption {
+	return func(h *hook) {
+		h.priority = priority
+	}
+}
+
+// WithHookName names a hook in logs and veto errors.
+func WithHookName(name string) HookOption {
+	return func(h *hook) {
+		h.name = name
+	}
+}
+
+// HookVetoError is returned when a Before hook vetoes a phase.
+type HookVetoError struct {
+	Phase     HookPhase
+	EntryType string
+	EntryName string
+	Hook      string
+	Err       error
+}
+
+func (e *HookVetoError) Error() string {
+	return fmt.Sprintf("%s of [%s] %s vetoed by hook %s: %v", e.Phase, e.EntryType, e.EntryName, e.Hook, e.Err)
+}
+
+func (e *HookVetoError) Unwrap() error {
+	return e.Err
+}
+
+// hook is a registered hook with its scope.
+type hook struct {
+	name      string
+	phase     HookPhase
+	entryType string // "" or "*" matches any type
+	entryName string // "" or "*" matches any name, otherwise a path.Match pattern
+	exact     bool   // Legacy hook: type and name match literally, re-registering replaces it
+	priority  int
+	seq       uint64 // Registration order
+	fn        HookFunc
+}
+
+// matches reports whether the hook applies to an Entry.
+func (h *hook) matches(entryType, entryName string) bool {
+	if h.exact {
+		return h.entryType == entryType && h.entryName == entryName
+	}
+	if h.entryType != "" && h.entryType != "*" && h.entryType != entryType {
+		return false
+	}
+	if h.entryName == "" || h.entryName == "*" || h.entryName == entryName {
+		return true
+	}
+	ok, err := path.Match(h.entryName, entryName)
+	return err == nil && ok
+}
+
+// hookRegistry holds the hooks of a Boot, sorted by phase order.
+type hookRegistry struct {
+	hooks   []*hook
+	nextSeq uint64
+	mu      sync.RWMutex
+}
+
+// add registers a hook. An exact hook replaces the exact hook of the same phase,
+// type and name, if any.
+func (r *hookRegistry) add(h *hook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if h.exact {
+		kept := r.hooks[:0]
+		for _, old := range r.hooks {
+			if !old.exact || old.phase != h.phase || old.entryType != h.entryType || old.entryName != h.entryName {
+				kept = append(kept, old)
+			}
+		}
+		r.hooks = kept
+	}
+	r.nextSeq++
+	h.seq = r.nextSeq
+	if h.name == "" {
+		h.name = fmt.Sprintf("#%d", h.seq)
+	}
+	r.hooks = append(r.hooks, h)
+	sort.SliceStable(r.hooks, func(i, j int) bool {
+		if r.hooks[i].priority != r.hooks[j].priority {
+			return r.hooks[i].priority < r.hooks[j].priority
+		}
+		return r.hooks[i].seq < r.hooks[j].seq
+	})
+}
+
+// has reports whether any hook is registered for the phase.
+func (r *hookRegistry) has(phase HookPhase) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, h := range r.hooks {
+		if h.phase == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// run calls the hooks matching the event in order.
+// In veto phases it stops at the first error and returns a *HookVetoError;
+// otherwise it runs every hook and returns their joined errors.
+func (r *hookRegistry) run(ctx context.Context, event HookEvent) error {
+	r.mu.RLock()
+	var matched []*hook
+	for _, h := range r.hooks {
+		if h.phase == event.Phase && h.matches(event.EntryType, event.EntryName) {
+			matched = append(matched, h)
+		}
+	}
+	r.mu.RUnlock()
+
+	var errs []error
+	for _, h := range matched {
+		if err := callHook(ctx, h, event); err != nil {
+			if event.Phase.vetoes() {
+				return &HookVetoError{Phase: event.Phase, EntryType: event.EntryType, EntryName: event.EntryName, Hook: h.name, Err: err}
+			}
+			errs = append(errs, fmt.Errorf("hook %s: %w", h.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// callHook runs a hook, turning a panic into an error.
+func callHook(ctx context.Context, h *hook, event HookEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return h.fn(ctx, event)
+}
+
+// AddHook registers a hook for a phase on the Entries matching entryType and entryName.
+// Use "*" (or "") to match any type or name; names also accept path.Match
+// patterns such as "official-*". Register hooks before Bootstrap.
+//
+// Parameters:
+//   - phase: lifecycle phase, e.g. HookBeforeBootstrap
+//   - entryType: Entry type, or "*" for every type
+//   - entryName: Entry name or pattern, or "*" for every name
+//   - fn: the hook; in Before phases a returned error vetoes the phase
+//   - opts: WithHookPriority, WithHookName
+func (b *Boot) AddHook(phase HookPhase, entryType, entryName string, fn HookFunc, opts ...HookOption) {
+	if fn == nil {
+		return
+	}
+	h := &hook{phase: phase, entryType: entryType, entryName: entryName, fn: fn}
+	for _, opt := range opts {
+		opt(h)
+	}
+	b.hooks.add(h)
+}
+
+// AddGlobalHook registers a hook for a phase on every Entry.
+func (b *Boot) AddGlobalHook(phase HookPhase, fn HookFunc, opts ...HookOption) {
+	b.AddHook(phase, "*", "*", fn, opts...)
+}
+
+// AddTypeHook registers a hook for a phase on every Entry of a type.
+func (b *Boot) AddTypeHook(phase HookPhase, entryType string, fn HookFunc, opts ...HookOption) {
+	b.AddHook(phase, entryType, "*", fn, opts...)
+}
+
+// runHooks runs the hooks of a phase for an Entry, logging errors of non-veto phases.
+func (b *Boot) runHooks(ctx context.Context, event HookEvent) error {
+	err := b.hooks.run(ctx, event)
+	if err != nil && !event.Phase.vetoes() {
+		b.logger.Warn(fmt.Sprintf("%s hooks of [%s] %s failed: %v", event.Phase, event.EntryType, event.EntryName, err))
+	}
+	return err
+}
+
+// attachHooks wires the reload and status change hooks of a plugin Entry's instance.
+func (b *Boot) attachHooks(entryType, entryName string, entry Entry) {
+	pluginEntry, ok := entry.(*PluginEntry)
+	if !ok {
+		return
+	}
+	instance := pluginEntry.Instance()
+
+	instance.SetReloadHook(func(ctx context.Context, after bool, oldConfig, newConfig interface{}, err error) error {
+		event := HookEvent{
+			Phase:     HookBeforeReload,
+			EntryType: entryType,
+			EntryName: entryName,
+			Entry:     entry,
+			OldConfig: oldConfig,
+			NewConfig: newConfig,
+			Err:       err,
+		}
+		if after {
+			event.Phase = HookAfterReload
+		}
+		return b.runHooks(ctx, event)
+	})
+
+	if !b.hooks.has(HookStatusChange) {
+		return
+	}
+	sub := instance.Subscribe(0)
+	go func() {
+		replayed := false
+		for status := range sub.Events() {
+			if !replayed {
+				replayed = true // Skip the replay of the current status
+				continue
+			}
+			_ = b.runHooks(context.Background(), HookEvent{
+				Phase:     HookStatusChange,
+				EntryType: entryType,
+				EntryName: entryName,
+				Entry:     entry,
+				Status:    status,
+			})
+		}
+	}()
+	b.mu.Lock()
+	b.statusSubs = append(b.statusSubs, hookSubscription{instance: instance, sub: sub})
+	b.mu.Unlock()
+}
+
+// hookSubscription is a status subscription feeding HookStatusChange hooks.
+type hookSubscription struct {
+	instance *PluginInstance
+	sub      *StatusSubscription
+}
+
+// detachHooks ends the status change subscriptions, once Entries are interrupted.
+func (b *Boot) detachHooks() {
+	b.mu.Lock()
+	subs := b.statusSubs
+	b.statusSubs = nil
+	b.mu.Unlock()
+
+	for _, s := range subs {
+		s.instance.Unsubscribe(s.sub)
+	}
+}
+
+// detachEntryHooks unwires the hooks of a plugin Entry's instance, once it is removed.
+func (b *Boot) detachEntryHooks(entry Entry) {
+	pluginEntry, ok := entry.(*PluginEntry)
+	if !ok {
+		return
+	}
+	instance := pluginEntry.Instance()
+	instance.SetReloadHook(nil)
+
+	b.mu.Lock()
+	var detached []hookSubscription
+	kept := b.statusSubs[:0]
+	for _, s := range b.statusSubs {
+		if s.instance == instance {
+			detached = append(detached, s)
+		} else {
+			kept = append(kept, s)
+		}
+	}
+	b.statusSubs = kept
+	b.mu.Unlock()
+
+	for _, s := range detached {
+		s.instance.Unsubscribe(s.sub)
+	}
+}
@@ -0,0 +1,94 @@
+package plugGo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// recordHook returns a hook adding "<label> <entry name>" to the log.
+func recordHook(log *callLog, label string) HookFunc {
+	return func(ctx context.Context, event HookEvent) error {
+		log.add(label + " " + event.EntryName)
+		return nil
+	}
+}
+
+func TestHookScopeAndPriority(t *testing.T) {
+	log := &callLog{}
+	boot := newTestBoot(t, []Entry{
+		&stubEntry{name: "official-cn", log: log},
+		&stubEntry{name: "community", log: log},
+	})
+	boot.AddHook(HookBeforeBootstrap, "stub-entry", "official-*", recordHook(log, "pattern"))
+	boot.AddHook(HookBeforeBootstrap, "other-type", "*", recordHook(log, "other-type"))
+	boot.AddGlobalHook(HookBeforeBootstrap, recordHook(log, "late"), WithHookPriority(10))
+	boot.AddTypeHook(HookBeforeBootstrap, "stub-entry", recordHook(log, "early"), WithHookPriority(-10))
+	boot.AddGlobalHook(HookAfterBootstrap, recordHook(log, "after"))
+
+	if err := boot.Bootstrap(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"early community", "late community", "bootstrap community", "after community",
+		"early official-cn", "pattern official-cn", "late official-cn", "bootstrap official-cn", "after official-cn",
+	}
+	if got := log.get(); !equalStrings(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestHookVeto(t *testing.T) {
+	log := &callLog{}
+	boot := newTestBoot(t, []Entry{&stubEntry{name: "vetoed", log: log}, &stubEntry{name: "kept", log: log}})
+	errMaintenance := errors.New("maintenance window")
+	boot.AddHook(HookBeforeBootstrap, "*", "vetoed", func(ctx context.Context, event HookEvent) error {
+		return errMaintenance
+	}, WithHookName("maintenance"))
+	boot.AddHook(HookBeforeInterrupt, "*", "kept", func(ctx context.Context, event HookEvent) error {
+		return errMaintenance
+	})
+
+	var bootErr *BootstrapError
+	if err := boot.Bootstrap(context.Background()); !errors.As(err, &bootErr) || len(bootErr.Failed) != 1 {
+		t.Fatalf("Bootstrap = %v, want one failed Entry", err)
+	}
+	if failed := bootErr.Failed[0]; failed.Name != "vetoed" || failed.Outcome != OutcomeError || !strings.Contains(failed.Error, "vetoed by hook maintenance") {
+		t.Errorf("failed = %+v", failed)
+	}
+
+	// A BeforeInterrupt veto keeps the Entry running, except during Shutdown
+	if err := boot.RestartEntry(context.Background(), "stub-entry", "kept"); err == nil {
+		t.Error("RestartEntry succeeded despite the veto")
+	}
+	report := boot.Shutdown(context.Background())
+	if got := resultSummaries(report.Entries); !equalStrings(got, []string{"kept ok"}) {
+		t.Errorf("interrupted = %v", got)
+	}
+	if got, want := log.get(), []string{"bootstrap kept", "interrupt kept"}; !equalStrings(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestLegacyHooksMatchExactlyAndReplace(t *testing.T) {
+	log := &callLog{}
+	boot := newTestBoot(t, []Entry{&stubEntry{name: "a", log: log}, &stubEntry{name: "ab", log: log}})
+	record := func(label string) func(ctx context.Context) {
+		return func(ctx context.Context) { log.add(label) }
+	}
+	boot.AddHookFuncBeforeBootstrap("stub-entry", "a", record("replaced"))
+	boot.AddHookFuncBeforeBootstrap("stub-entry", "a", record("before a"))
+	boot.AddHookFuncBeforeBootstrap("stub-entry", "a*", record("pattern"))
+	boot.AddHookFuncBeforeBootstrap("stub-entry", "", record("empty name"))
+	boot.AddHookFuncAfterBootstrap("*", "ab", record("wildcard type"))
+	boot.AddHookFuncAfterBootstrap("stub-entry", "ab", record("after ab"))
+
+	if err := boot.Bootstrap(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"before a", "bootstrap a", "bootstrap ab", "after ab"}
+	if got := log.get(); !equalStrings(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
+
+	"github.com/seencxy/plugGo/config"
+	"github.com/seencxy/plugGo/schedule"
 )
 
 // PluginInstance is the plugin instance wrapper.
-// Encapsulates plugin instance, config and metadata.
+// Encapsulates plugin instance, config and metadata, and enforces the plugin
+// lifecycle state machine (see CanTransition) so plugins only implement the actual work.
 type PluginInstance struct {
 	id         string        // Instance unique identifier
 	pluginType string        // Plugin type name
@@ -15,6 +20,32 @@ type PluginInstance struct {
 	config     interface{}   // Current config
 	factory    PluginFactory // Factory that created this instance
 	mu         sync.RWMutex  // Protects concurrent access
+
+	// Lifecycle state machine
+	status         PluginStatus // Current lifecycle status (guarded by mu)
+	lastTransition StatusEvent  // Most recent transition (guarded by mu)
+	opMu           sync.Mutex   // Serializes Start, Stop, Restart and Reload
+	started        bool         // plugin.Start succeeded and no plugin.Stop did since (guarded by opMu)
+	retired        bool         // Stopped for good by Retire, Start fails (guarded by opMu)
+
+	// Lifecycle history
+	history    *historyRing   // Bounded lifecycle event history
+	auditSink  AuditSink      // Optional durable audit sink (guarded by mu)
+	auditQueue []HistoryEvent // Events not yet written to auditSink (guarded by mu)
+	auditMu    sync.Mutex     // Serializes writes to auditSink, outside mu
+
+	reloadHook ReloadHook        // Optional hook around config reloads (guarded by mu)
+	labels     map[string]string // Labels for registry selectors (guarded by mu)
+
+	// Status fan-out
+	broadcaster *statusBroadcaster // Delivers status events to subscribers
+	pumpStop    chan struct{}      // Closed to stop consuming plugin status events
+	pumpDone    chan struct{}      // Closed when the consuming goroutine exits
+	pumpMu      sync.Mutex         // Protects pumpStop and pumpDone
+
+	// Scheduled jobs (see ScheduledPlugin)
+	clock     schedule.Clock      // Clock of the job scheduler (guarded by mu)
+	scheduler *schedule.Scheduler // Jobs of the running plugin, nil when none (guarded by mu)
 }
 
 // NewPluginInstance creates a new plugin instance wrapper.
@@ -25,6 +56,16 @@ func NewPluginInstance(id string, pluginType string, plugin Plugin, config inter
 		plugin:     plugin,
 		config:     config,
 		factory:    factory,
+		status:     StatusIdle,
+		lastTransition: StatusEvent{
+			Status: StatusIdle,
+			From:   StatusIdle,
+			Reason: "created",
+			Time:   time.Now(),
+		},
+		history:     newHistoryRing(DefaultHistorySize),
+		broadcaster: newStatusBroadcaster(),
+		clock:       schedule.RealClock,
 	}
 }
 
@@ -43,6 +84,51 @@ func (pi *PluginInstance) Plugin() Plugin {
 	return pi.plugin
 }
 
+// Drain lets a running plugin implementing Drainer finish its pending work.
+// It does nothing for other plugins or when the plugin isn't running.
+func (pi *PluginInstance) Drain(ctx context.Context) error {
+	drainer, ok := pi.plugin.(Drainer)
+	if !ok || pi.Status() != StatusRunning {
+		return nil
+	}
+	return drainer.Drain(ctx)
+}
+
+// Health returns the component health reported by the plugin,
+// or nil if the plugin doesn't implement HealthReporter.
+func (pi *PluginInstance) Health() []ComponentHealth {
+	if reporter, ok := pi.plugin.(HealthReporter); ok {
+		return reporter.Health()
+	}
+	return nil
+}
+
+// Labels returns a copy of the instance labels, e.g. {"team": "payments"}.
+func (pi *PluginInstance) Labels() map[string]string {
+	pi.mu.RLock()
+	defer pi.mu.RUnlock()
+
+	labels := make(map[string]string, len(pi.labels))
+	for k, v := range pi.labels {
+		labels[k] = v
+	}
+	return labels
+}
+
+// SetLabels replaces the instance labels with a copy of labels.
+// Note: relabel registered instances with registry.SetLabels, which keeps the
+// registry's label index current.
+func (pi *PluginInstance) SetLabels(labels map[string]string) {
+	copied := make(map[string]string, len(labels))
+	for k, v := range labels {
+		copied[k] = v
+	}
+
+	pi.mu.Lock()
+	defer pi.mu.Unlock()
+	pi.labels = copied
+}
+
 // GetConfig returns current config (returns reference, caller handles concurrency).
 func (pi *PluginInstance) GetConfig() interface{} {
 	pi.mu.RLock()
@@ -51,39 +137,484 @@ func (pi *PluginInstance) GetConfig() interface{} {
 }
 
 // UpdateConfig updates config and reloads the plugin.
+// A running plugin goes through Reloading; an idle, stopped or failed plugin only
+// receives the config for its next Start. A plugin that failed while running is still
+// started, so it is stopped and started again with the new config. Returns a
+// *TransitionError while the plugin is starting, stopping or already reloading.
 func (pi *PluginInstance) UpdateConfig(newConfig interface{}) error {
-	pi.mu.Lock()
-	defer pi.mu.Unlock()
+	return pi.UpdateConfigWithContext(context.Background(), newConfig)
+}
+
+// UpdateConfigWithContext is UpdateConfig with a context carrying the actor (see WithActor).
+func (pi *PluginInstance) UpdateConfigWithContext(ctx context.Context, newConfig interface{}) error {
+	pi.opMu.Lock()
+	defer pi.opMu.Unlock()
+	return pi.updateConfig(ctx, newConfig)
+}
 
+// updateConfig validates, stores and reloads a new config, rolling back the stored config on failure.
+// Note: caller must hold pi.opMu.
+func (pi *PluginInstance) updateConfig(ctx context.Context, newConfig interface{}) error {
 	// Validate new config
 	if err := pi.factory.ValidateConfig(newConfig); err != nil {
 		return fmt.Errorf("config validation failed: %w", err)
 	}
 
+	pi.mu.RLock()
+	hook := pi.reloadHook
+	pi.mu.RUnlock()
+	if hook != nil {
+		if err := hook(ctx, false, pi.GetConfig(), newConfig, nil); err != nil {
+			return err
+		}
+	}
+
 	// Save old config for rollback
+	pi.mu.Lock()
 	oldConfig := pi.config
-
-	// Update config
 	pi.config = newConfig
+	pi.mu.Unlock()
+
+	from := pi.Status()
+	changes := configChangeSummary(oldConfig, newConfig)
 
 	// Reload plugin
-	if err := pi.plugin.Reload(newConfig); err != nil {
+	err := pi.reload(ctx, oldConfig, newConfig)
+	if err != nil {
 		// Rollback config
+		pi.mu.Lock()
 		pi.config = oldConfig
-		return fmt.Errorf("failed to reload plugin: %w", err)
+		pi.mu.Unlock()
 	}
 
-	return nil
+	pi.recordOperation(ctx, HistoryReload, from, err, changes)
+	if hook != nil {
+		_ = hook(ctx, true, oldConfig, newConfig, err)
+	}
+	return err
+}
+
+// reload applies a config to the plugin according to the lifecycle state.
+// Note: caller must hold pi.opMu.
+func (pi *PluginInstance) reload(ctx context.Context, oldConfig, newConfig interface{}) error {
+	status := pi.Status()
+	if status == StatusError && pi.started {
+		// Failed while running: the plugin still runs with the old config, restart it
+		if err := pi.stop(ctx); err != nil {
+			return fmt.Errorf("failed to stop plugin: %w", err)
+		}
+		if err := pi.plugin.Reload(newConfig); err != nil {
+			return fmt.Errorf("failed to reload plugin: %w", err)
+		}
+		return pi.start(ctx)
+	}
+
+	switch status {
+	case StatusRunning:
+		if err := pi.transition("reload", StatusReloading, "reload requested", nil); err != nil {
+			return err
+		}
+		pi.stopJobs()
+		if err := pi.reloadPlugin(oldConfig, newConfig); err != nil {
+			_ = pi.transition("reload", StatusError, "reload failed", err)
+			return fmt.Errorf("failed to reload plugin: %w", err)
+		}
+		if err := pi.transition("reload", StatusRunning, "reloaded", nil); err != nil {
+			return err
+		}
+		pi.startJobs()
+		return nil
+	case StatusIdle, StatusStopped, StatusError:
+		// Not running: the plugin only stores the config for its next start
+		if err := pi.plugin.Reload(newConfig); err != nil {
+			return fmt.Errorf("failed to reload plugin: %w", err)
+		}
+		return nil
+	default:
+		return &TransitionError{Op: "reload", From: status, To: StatusReloading}
+	}
+}
+
+// reloadPlugin hands the new config to a running plugin, with a diff if it opts in.
+func (pi *PluginInstance) reloadPlugin(oldConfig, newConfig interface{}) error {
+	if reloader, ok := pi.plugin.(DiffReloader); ok {
+		if diff, err := config.Compare(oldConfig, newConfig); err == nil {
+			return reloader.ReloadWithDiff(newConfig, diff)
+		}
+	}
+	return pi.plugin.Reload(newConfig)
 }
 
 // Start starts the plugin with context.
+// Allowed from Idle, Stopped and Error; otherwise returns a *TransitionError.
+// Errors reported by the plugin through StatusNotify move a running instance to Error;
+// its plugin is still started then, so Start returns ErrStopRequired until Stop (or Restart).
 func (pi *PluginInstance) Start(ctx context.Context) error {
-	return pi.plugin.Start(ctx)
+	pi.opMu.Lock()
+	defer pi.opMu.Unlock()
+
+	from := pi.Status()
+	err := pi.start(ctx)
+	pi.recordOperation(ctx, HistoryStart, from, err, nil)
+	return err
+}
+
+// start runs the Starting transition.
+// Note: caller must hold pi.opMu.
+func (pi *PluginInstance) start(ctx context.Context) error {
+	if pi.retired {
+		return fmt.Errorf("cannot start plugin %s: %w", pi.id, ErrInstanceRetired)
+	}
+	if pi.started {
+		return fmt.Errorf("cannot start plugin in %s: %w", pi.Status(), ErrStopRequired)
+	}
+	if err := pi.transition("start", StatusStarting, "start requested", nil); err != nil {
+		return err
+	}
+
+	pi.startStatusPump()
+	if err := pi.plugin.Start(ctx); err != nil {
+		pi.stopStatusPump()
+		_ = pi.transition("start", StatusError, "start failed", err)
+		return err
+	}
+	pi.started = true
+
+	if err := pi.transition("start", StatusRunning, "started", nil); err != nil {
+		return err
+	}
+	pi.startJobs()
+	return nil
 }
 
 // Stop stops the plugin with context.
+// Stopping an already stopped plugin is a no-op; stopping a plugin that was
+// never started returns a *TransitionError. An instance in Error whose plugin
+// failed to start moves to Stopped without calling the plugin's Stop.
 func (pi *PluginInstance) Stop(ctx context.Context) error {
-	return pi.plugin.Stop(ctx)
+	pi.opMu.Lock()
+	defer pi.opMu.Unlock()
+
+	from := pi.Status()
+	if from == StatusStopped {
+		return nil
+	}
+
+	err := pi.stop(ctx)
+	pi.recordOperation(ctx, HistoryStop, from, err, nil)
+	return err
+}
+
+// stop runs the Stopping transition.
+// Note: caller must hold pi.opMu.
+func (pi *PluginInstance) stop(ctx context.Context) error {
+	if err := pi.transition("stop", StatusStopping, "stop requested", nil); err != nil {
+		return err
+	}
+
+	pi.stopJobs()
+	if !pi.started {
+		// Failed to start: there is nothing to stop
+		pi.stopStatusPump()
+		return pi.transition("stop", StatusStopped, "stopped, plugin was not started", nil)
+	}
+	err := pi.plugin.Stop(ctx)
+	pi.stopStatusPump()
+	if err != nil {
+		// Still considered started: Start needs a successful Stop first
+		_ = pi.transition("stop", StatusError, "stop failed", err)
+		return err
+	}
+	pi.started = false
+
+	return pi.transition("stop", StatusStopped, "stopped", nil)
+}
+
+// Retire stops the plugin, if needed, and makes every later Start and Restart fail
+// with ErrInstanceRetired, e.g. before the instance is removed from a registry.
+// Both happen under the instance's operation lock, so no Start can slip in between.
+// The instance is not retired if it fails to stop.
+func (pi *PluginInstance) Retire(ctx context.Context) error {
+	pi.opMu.Lock()
+	defer pi.opMu.Unlock()
+
+	if pi.retired {
+		return nil
+	}
+	if from := pi.Status(); from != StatusIdle && from != StatusStopped {
+		err := pi.stop(ctx)
+		pi.recordOperation(ctx, HistoryStop, from, err, nil)
+		if err != nil {
+			return err
+		}
+	}
+	pi.retired = true
+	return nil
+}
+
+// Restart stops the plugin (if running or failed) and starts it again.
+func (pi *PluginInstance) Restart(ctx context.Context) error {
+	pi.opMu.Lock()
+	defer pi.opMu.Unlock()
+
+	from := pi.Status()
+	err := pi.restart(ctx)
+	pi.recordOperation(ctx, HistoryRestart, from, err, nil)
+	return err
+}
+
+// restart runs stop (when needed) followed by start.
+// Note: caller must hold pi.opMu.
+func (pi *PluginInstance) restart(ctx context.Context) error {
+	switch pi.Status() {
+	case StatusRunning, StatusError:
+		if err := pi.stop(ctx); err != nil {
+			return fmt.Errorf("failed to stop plugin: %w", err)
+		}
+	}
+	return pi.start(ctx)
+}
+
+// SetClock sets the clock of the job scheduler, e.g. a schedule.FakeClock in tests.
+// Takes effect the next time jobs are scheduled (Start or Reload).
+func (pi *PluginInstance) SetClock(clock schedule.Clock) {
+	pi.mu.Lock()
+	defer pi.mu.Unlock()
+	pi.clock = clock
+}
+
+// Jobs returns the scheduled jobs of the running plugin (nil if none).
+func (pi *PluginInstance) Jobs() []schedule.Entry {
+	pi.mu.RLock()
+	scheduler := pi.scheduler
+	pi.mu.RUnlock()
+	if scheduler == nil {
+		return nil
+	}
+	return scheduler.Entries()
+}
+
+// startJobs schedules the jobs of a ScheduledPlugin.
+// Jobs with an invalid schedule are logged and skipped.
+// Note: caller must hold pi.opMu.
+func (pi *PluginInstance) startJobs() {
+	pi.stopJobs()
+	scheduled, ok := pi.plugin.(ScheduledPlugin)
+	if !ok {
+		return
+	}
+	jobs := scheduled.ScheduledJobs()
+	if len(jobs) == 0 {
+		return
+	}
+
+	pi.mu.RLock()
+	clock := pi.clock
+	pi.mu.RUnlock()
+
+	logger := pi.plugin.GetLogger()
+	scheduler := schedule.NewScheduler(
+		schedule.WithClock(clock),
+		schedule.WithErrorHandler(func(name string, err error) {
+			logger.Error(fmt.Sprintf("[%s] Scheduled job %s failed: %v", pi.id, name, err))
+		}),
+	)
+	for _, job := range jobs {
+		if err := scheduler.AddFunc(job.Name, job.Schedule, job.Run); err != nil {
+			logger.Error(fmt.Sprintf("[%s] Invalid scheduled job: %v", pi.id, err))
+		}
+	}
+	scheduler.Start()
+
+	pi.mu.Lock()
+	pi.scheduler = scheduler
+	pi.mu.Unlock()
+}
+
+// stopJobs cancels the scheduled jobs and waits for running ones to return.
+// Note: caller must hold pi.opMu.
+func (pi *PluginInstance) stopJobs() {
+	pi.mu.Lock()
+	scheduler := pi.scheduler
+	pi.scheduler = nil
+	pi.mu.Unlock()
+
+	if scheduler != nil {
+		scheduler.Stop()
+	}
+}
+
+// History returns the recorded lifecycle events from oldest to newest.
+func (pi *PluginInstance) History() []HistoryEvent {
+	return pi.history.snapshot()
+}
+
+// LastError returns the most recent error event, answering "when did it last fail and why".
+func (pi *PluginInstance) LastError() (HistoryEvent, bool) {
+	events := pi.history.snapshot()
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Kind == HistoryError {
+			return events[i], true
+		}
+	}
+	return HistoryEvent{}, false
+}
+
+// SetHistorySize changes how many lifecycle events are kept, dropping the oldest ones.
+func (pi *PluginInstance) SetHistorySize(size int) {
+	pi.history.resize(size)
+}
+
+// SetAuditSink sets the sink receiving every history event (nil disables auditing).
+func (pi *PluginInstance) SetAuditSink(sink AuditSink) {
+	pi.mu.Lock()
+	defer pi.mu.Unlock()
+	pi.auditSink = sink
+}
+
+// ReloadHook is called around config reloads of an instance: before the reload with
+// after false, where an error refuses the reload, and after it with after true and
+// the reload result in err, where the returned error is ignored.
+type ReloadHook func(ctx context.Context, after bool, oldConfig, newConfig interface{}, err error) error
+
+// SetReloadHook sets the hook called around config reloads (nil removes it).
+// Boot sets it on plugin Entries to run HookBeforeReload and HookAfterReload hooks.
+func (pi *PluginInstance) SetReloadHook(hook ReloadHook) {
+	pi.mu.Lock()
+	defer pi.mu.Unlock()
+	pi.reloadHook = hook
+}
+
+// recordOperation records the outcome of a lifecycle operation.
+func (pi *PluginInstance) recordOperation(ctx context.Context, kind HistoryEventKind, from PluginStatus, err error, changes []string) {
+	event := HistoryEvent{
+		Kind:          kind,
+		Actor:         ActorFromContext(ctx),
+		From:          from,
+		Message:       "ok",
+		ConfigChanges: changes,
+	}
+	if err != nil {
+		event.Message = "failed"
+		event.Error = err.Error()
+	}
+
+	pi.mu.Lock()
+	event.To = pi.status
+	pi.recordLocked(event)
+	pi.mu.Unlock()
+	pi.flushAudit()
+}
+
+// recordLocked stamps an event, stores it in the history and queues it for the audit sink.
+// Note: caller must hold the lock (pi.mu) and call flushAudit after releasing it.
+func (pi *PluginInstance) recordLocked(event HistoryEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	event.InstanceID = pi.id
+	event.PluginType = pi.pluginType
+	pi.history.add(event)
+
+	if pi.auditSink != nil {
+		pi.auditQueue = append(pi.auditQueue, event)
+	}
+}
+
+// flushAudit writes the queued events to the audit sink in order.
+// The sink is called without holding pi.mu, so a slow sink doesn't block Status,
+// GetConfig or subscribers.
+func (pi *PluginInstance) flushAudit() {
+	pi.auditMu.Lock()
+	defer pi.auditMu.Unlock()
+
+	pi.mu.Lock()
+	events, sink := pi.auditQueue, pi.auditSink
+	pi.auditQueue = nil
+	pi.mu.Unlock()
+
+	if sink == nil {
+		return
+	}
+	for _, event := range events {
+		if err := sink.Record(event); err != nil {
+			if logger := pi.plugin.GetLogger(); logger != nil {
+				logger.Warn(fmt.Sprintf("Failed to write audit record: %v", err))
+			}
+		}
+	}
+}
+
+// LastTransition returns the most recent lifecycle transition.
+func (pi *PluginInstance) LastTransition() StatusEvent {
+	pi.mu.RLock()
+	defer pi.mu.RUnlock()
+	return pi.lastTransition
+}
+
+// transition moves the instance to a new status, records it and notifies subscribers.
+// Returns a *TransitionError if the state machine does not allow the move.
+func (pi *PluginInstance) transition(op string, to PluginStatus, reason string, err error) error {
+	pi.mu.Lock()
+	terr := pi.transitionLocked(op, to, reason, err)
+	pi.mu.Unlock()
+	pi.flushAudit()
+	return terr
+}
+
+// transitionLocked is transition without locking.
+// Note: caller must hold the lock (pi.mu) and call flushAudit after releasing it.
+func (pi *PluginInstance) transitionLocked(op string, to PluginStatus, reason string, err error) error {
+	from := pi.status
+	if !CanTransition(from, to) {
+		return &TransitionError{Op: op, From: from, To: to}
+	}
+
+	event := StatusEvent{
+		Status: to,
+		Error:  err,
+		From:   from,
+		Reason: reason,
+		Time:   time.Now(),
+	}
+	pi.status = to
+	pi.lastTransition = event
+
+	history := HistoryEvent{
+		Time:    event.Time,
+		Kind:    HistoryStatusChange,
+		From:    from,
+		To:      to,
+		Message: reason,
+	}
+	if err != nil {
+		history.Error = err.Error()
+	}
+	pi.recordLocked(history)
+	if to == StatusError {
+		history.Kind = HistoryError
+		pi.recordLocked(history)
+	}
+
+	// Publish while holding the lock so subscribers see transitions in order
+	pi.broadcaster.publish(event)
+	return nil
+}
+
+// handlePluginEvent reacts to a status event reported by the plugin itself.
+// Transitions are driven by the instance, so only asynchronous errors of a
+// running plugin are taken into account.
+func (pi *PluginInstance) handlePluginEvent(event StatusEvent) {
+	if event.Status != StatusError {
+		return
+	}
+
+	pi.mu.Lock()
+	if pi.status == StatusRunning {
+		_ = pi.transitionLocked("report", StatusError, "plugin reported error", event.Error)
+	}
+	pi.mu.Unlock()
+	pi.flushAudit()
 }
 
 // GetLogger returns the logger.
@@ -96,16 +627,97 @@ func (pi *PluginInstance) SetLogger(logger Logger) {
 	pi.plugin.SetLogger(logger)
 }
 
-// Status returns the current plugin status.
+// Status returns the current lifecycle status enforced by the instance.
 func (pi *PluginInstance) Status() PluginStatus {
-	return pi.plugin.Status()
+	pi.mu.RLock()
+	defer pi.mu.RUnlock()
+	return pi.status
 }
 
 // StatusNotify returns a read-only channel for receiving status change events.
+//
+// Deprecated: the channel is consumed by the instance while the plugin runs, use Subscribe instead.
 func (pi *PluginInstance) StatusNotify() <-chan StatusEvent {
 	return pi.plugin.StatusNotify()
 }
 
+// Subscribe returns a new subscription receiving this instance's status events.
+// The last transition is replayed as the first event. Each subscriber has its own
+// buffer of bufferSize events (DefaultSubscriptionBuffer if not positive).
+func (pi *PluginInstance) Subscribe(bufferSize int) *StatusSubscription {
+	// Hold the lock so no transition slips between replay and registration
+	pi.mu.RLock()
+	defer pi.mu.RUnlock()
+	return pi.broadcaster.subscribe(bufferSize, pi.lastTransition)
+}
+
+// Unsubscribe cancels a subscription and closes its channel.
+// Returns false if the subscription does not belong to this instance.
+func (pi *PluginInstance) Unsubscribe(sub *StatusSubscription) bool {
+	return pi.broadcaster.unsubscribe(sub)
+}
+
+// UnsubscribeAll cancels every subscription of this instance.
+func (pi *PluginInstance) UnsubscribeAll() {
+	pi.broadcaster.unsubscribeAll()
+}
+
+// SubscriberCount returns the number of active subscriptions.
+func (pi *PluginInstance) SubscriberCount() int {
+	return pi.broadcaster.count()
+}
+
+// startStatusPump starts consuming the plugin's status channel.
+func (pi *PluginInstance) startStatusPump() {
+	pi.pumpMu.Lock()
+	defer pi.pumpMu.Unlock()
+
+	if pi.pumpStop != nil {
+		return
+	}
+	src := pi.plugin.StatusNotify()
+	if src == nil {
+		return
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	pi.pumpStop, pi.pumpDone = stop, done
+
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case event := <-src:
+				pi.handlePluginEvent(event)
+			case <-stop:
+				// Handle events the plugin emitted before stopping
+				for {
+					select {
+					case event := <-src:
+						pi.handlePluginEvent(event)
+					default:
+						return
+					}
+				}
+			}
+		}
+	}()
+}
+
+// stopStatusPump stops consuming and waits for the consuming goroutine to exit.
+func (pi *PluginInstance) stopStatusPump() {
+	pi.pumpMu.Lock()
+	defer pi.pumpMu.Unlock()
+
+	if pi.pumpStop == nil {
+		return
+	}
+	close(pi.pumpStop)
+	<-pi.pumpDone
+	pi.pumpStop, pi.pumpDone = nil, nil
+}
+
 // GetNotifyChannel returns the plugin's notification channel.
 // This delegates to the plugin's GetNotifyChannel() method.
 // Returns nil if the plugin doesn't support external notifications.
@@ -0,0 +1,212 @@
+package plugGo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubConfig is the config of stub plugins.
+type stubConfig struct {
+	Value   string
+	Invalid bool // Rejected by stubFactory.ValidateConfig
+}
+
+// stubPlugin is a plugin recording its calls, with injectable failures.
+type stubPlugin struct {
+	id       string
+	logger   Logger
+	statusCh chan StatusEvent
+
+	mu        sync.Mutex
+	calls     []string          // "start", "stop", "reload:<value>"
+	running   bool              // Between a successful Start and Stop
+	config    *stubConfig       // Last config passed to Reload
+	startErr  error             // Returned by Start
+	stopErr   error             // Returned by Stop
+	reloadErr func() error      // Called by Reload, nil to succeed
+	stopDelay time.Duration     // Stop waits this long (or until ctx is done)
+	onCall    func(call string) // Optional, called for every recorded call
+}
+
+func newStubPlugin(id string) *stubPlugin {
+	return &stubPlugin{id: id, logger: NewDefaultLogger(id), statusCh: make(chan StatusEvent, 4)}
+}
+
+func (p *stubPlugin) record(call string) {
+	p.mu.Lock()
+	p.calls = append(p.calls, call)
+	onCall := p.onCall
+	p.mu.Unlock()
+	if onCall != nil {
+		onCall(p.id + " " + call)
+	}
+}
+
+func (p *stubPlugin) Start(ctx context.Context) error {
+	p.record("start")
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.startErr != nil {
+		return p.startErr
+	}
+	p.running = true
+	return nil
+}
+
+func (p *stubPlugin) Stop(ctx context.Context) error {
+	p.record("stop")
+	p.mu.Lock()
+	delay := p.stopDelay
+	p.mu.Unlock()
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopErr != nil {
+		return p.stopErr
+	}
+	p.running = false
+	return nil
+}
+
+func (p *stubPlugin) Reload(config interface{}) error {
+	cfg := config.(*stubConfig)
+	p.record("reload:" + cfg.Value)
+	p.mu.Lock()
+	reloadErr := p.reloadErr
+	p.mu.Unlock()
+	if reloadErr != nil {
+		if err := reloadErr(); err != nil {
+			return err
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.config = cfg
+	return nil
+}
+
+func (p *stubPlugin) Calls() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.calls...)
+}
+
+func (p *stubPlugin) Running() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.running
+}
+
+func (p *stubPlugin) GetLogger() Logger                { return p.logger }
+func (p *stubPlugin) SetLogger(logger Logger)          { p.logger = logger }
+func (p *stubPlugin) Status() PluginStatus             { return StatusIdle }
+func (p *stubPlugin) StatusNotify() <-chan StatusEvent { return p.statusCh }
+func (p *stubPlugin) GetNotifyChannel() chan any       { return nil }
+func (p *stubPlugin) ID() string                       { return p.id }
+func (p *stubPlugin) PluginType() string               { return "stub" }
+func (p *stubPlugin) Version() string                  { return "1.0.0" }
+
+// stubFactory validates stub configs; instances are built with newStubInstance.
+type stubFactory struct{}
+
+func (stubFactory) Name() string               { return "stub" }
+func (stubFactory) Version() string            { return "1.0.0" }
+func (stubFactory) DefaultConfig() interface{} { return &stubConfig{} }
+func (stubFactory) ValidateConfig(config interface{}) error {
+	if cfg, ok := config.(*stubConfig); !ok || cfg.Invalid {
+		return errors.New("invalid stub config")
+	}
+	return nil
+}
+func (stubFactory) Create(instanceID string, config interface{}, logger Logger) (Plugin, error) {
+	return newStubPlugin(instanceID), nil
+}
+
+// newStubInstance wraps a new stub plugin with config value "v1".
+func newStubInstance(id string) (*PluginInstance, *stubPlugin) {
+	plugin := newStubPlugin(id)
+	return NewPluginInstance(id, "stub", plugin, &stubConfig{Value: "v1"}, stubFactory{}), plugin
+}
+
+// reportError sends an asynchronous error from a running plugin and waits until
+// the instance is in Error.
+func reportError(t *testing.T, instance *PluginInstance, plugin *stubPlugin) {
+	t.Helper()
+	plugin.statusCh <- StatusEvent{Status: StatusError, Error: errors.New("connection lost")}
+	deadline := time.Now().Add(time.Second)
+	for instance.Status() != StatusError {
+		if time.Now().After(deadline) {
+			t.Fatalf("status = %s, want Error", instance.Status())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestUpdateConfigRestartsPluginFailedWhileRunning(t *testing.T) {
+	instance, plugin := newStubInstance("failed-running")
+	ctx := context.Background()
+	if err := instance.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	reportError(t, instance, plugin)
+
+	if err := instance.UpdateConfig(&stubConfig{Value: "v2"}); err != nil {
+		t.Fatalf("UpdateConfig = %v", err)
+	}
+	if got, want := plugin.Calls(), []string{"start", "stop", "reload:v2", "start"}; !equalStrings(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+	if instance.Status() != StatusRunning {
+		t.Errorf("status = %s, want Running", instance.Status())
+	}
+	if cfg := instance.GetConfig().(*stubConfig); cfg.Value != "v2" {
+		t.Errorf("config = %q, want v2", cfg.Value)
+	}
+}
+
+func TestUpdateConfigOfStoppedPluginOnlyReloads(t *testing.T) {
+	instance, plugin := newStubInstance("not-running")
+	if err := instance.UpdateConfig(&stubConfig{Value: "v2"}); err != nil {
+		t.Fatalf("UpdateConfig = %v", err)
+	}
+	if got, want := plugin.Calls(), []string{"reload:v2"}; !equalStrings(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+	if instance.Status() != StatusIdle {
+		t.Errorf("status = %s, want Idle", instance.Status())
+	}
+
+	// A plugin whose Start failed is not started: only the config is handed over
+	plugin.startErr = errors.New("port in use")
+	if err := instance.Start(context.Background()); err == nil {
+		t.Fatal("Start succeeded")
+	}
+	if err := instance.UpdateConfig(&stubConfig{Value: "v3"}); err != nil {
+		t.Fatalf("UpdateConfig = %v", err)
+	}
+	if got, want := plugin.Calls(), []string{"reload:v2", "start", "reload:v3"}; !equalStrings(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -1,6 +1,10 @@
 package plugGo
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"time"
+)
 
 // PluginStatus represents the status of a plugin.
 type PluginStatus int
@@ -14,6 +18,12 @@ const (
 	StatusStopped
 	// StatusError indicates the plugin encountered an error.
 	StatusError
+	// StatusStarting indicates the plugin is being started.
+	StatusStarting
+	// StatusStopping indicates the plugin is being stopped.
+	StatusStopping
+	// StatusReloading indicates the plugin is applying a new config.
+	StatusReloading
 )
 
 // String returns the string representation of PluginStatus.
@@ -27,17 +37,45 @@ func (s PluginStatus) String() string {
 		return "Stopped"
 	case StatusError:
 		return "Error"
+	case StatusStarting:
+		return "Starting"
+	case StatusStopping:
+		return "Stopping"
+	case StatusReloading:
+		return "Reloading"
 	default:
 		return "Unknown"
 	}
 }
 
+// MarshalText encodes the status as its name, e.g. in JSON audit records.
+func (s PluginStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a status name produced by MarshalText.
+func (s *PluginStatus) UnmarshalText(text []byte) error {
+	for candidate := StatusIdle; candidate <= StatusReloading; candidate++ {
+		if candidate.String() == string(text) {
+			*s = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown plugin status: %s", text)
+}
+
 // StatusEvent represents a plugin status change event.
 type StatusEvent struct {
 	// Status is the current plugin status.
 	Status PluginStatus
 	// Error contains error information if Status is StatusError, nil otherwise.
 	Error error
+	// From is the status before the transition (set by PluginInstance).
+	From PluginStatus
+	// Reason describes what caused the transition (set by PluginInstance).
+	Reason string
+	// Time is when the transition happened (set by PluginInstance).
+	Time time.Time
 }
 
 type Application interface {
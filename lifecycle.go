@@ -0,0 +1,67 @@
+package plugGo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrIllegalTransition is matched (via errors.Is) by every TransitionError.
+var ErrIllegalTransition = errors.New("illegal lifecycle transition")
+
+// ErrStopRequired is returned by Start for an instance in Error whose plugin is
+// still started, e.g. after it reported an error while running. Stop it first,
+// or use Restart, so the plugin isn't started twice.
+var ErrStopRequired = errors.New("plugin is still started, stop it first")
+
+// ErrInstanceRetired is returned by Start and Restart for an instance retired with
+// Retire, e.g. while it is being removed from a registry.
+var ErrInstanceRetired = errors.New("instance is retired")
+
+// TransitionError is returned when a lifecycle operation is not allowed in the current status.
+type TransitionError struct {
+	Op   string       // Operation that was rejected (start, stop, reload)
+	From PluginStatus // Status at the time of the call
+	To   PluginStatus // Status the operation would have entered
+}
+
+// Error implements the error interface.
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("cannot %s plugin: illegal transition %s -> %s", e.Op, e.From, e.To)
+}
+
+// Is reports whether target is ErrIllegalTransition.
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrIllegalTransition
+}
+
+// allowedTransitions is the plugin lifecycle state machine.
+//
+//	Idle      -> Starting
+//	Starting  -> Running | Error
+//	Running   -> Stopping | Reloading | Error
+//	Reloading -> Running | Error
+//	Stopping  -> Stopped | Error
+//	Stopped   -> Starting
+//	Error     -> Starting | Stopping
+//
+// Leaving Error by Starting additionally requires the plugin to be stopped
+// (see ErrStopRequired).
+var allowedTransitions = map[PluginStatus][]PluginStatus{
+	StatusIdle:      {StatusStarting},
+	StatusStarting:  {StatusRunning, StatusError},
+	StatusRunning:   {StatusStopping, StatusReloading, StatusError},
+	StatusReloading: {StatusRunning, StatusError},
+	StatusStopping:  {StatusStopped, StatusError},
+	StatusStopped:   {StatusStarting},
+	StatusError:     {StatusStarting, StatusStopping},
+}
+
+// CanTransition reports whether the lifecycle state machine allows moving from one status to another.
+func CanTransition(from, to PluginStatus) bool {
+	for _, allowed := range allowedTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
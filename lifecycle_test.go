@@ -0,0 +1,130 @@
+package plugGo
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCanTransition(t *testing.T) {
+	allowed := map[[2]PluginStatus]bool{
+		{StatusIdle, StatusStarting}:     true,
+		{StatusStarting, StatusRunning}:  true,
+		{StatusStarting, StatusError}:    true,
+		{StatusRunning, StatusStopping}:  true,
+		{StatusRunning, StatusReloading}: true,
+		{StatusRunning, StatusError}:     true,
+		{StatusReloading, StatusRunning}: true,
+		{StatusReloading, StatusError}:   true,
+		{StatusStopping, StatusStopped}:  true,
+		{StatusStopping, StatusError}:    true,
+		{StatusStopped, StatusStarting}:  true,
+		{StatusError, StatusStarting}:    true,
+		{StatusError, StatusStopping}:    true,
+	}
+	for from := StatusIdle; from <= StatusReloading; from++ {
+		for to := StatusIdle; to <= StatusReloading; to++ {
+			if got := CanTransition(from, to); got != allowed[[2]PluginStatus{from, to}] {
+				t.Errorf("CanTransition(%s, %s) = %v", from, to, got)
+			}
+		}
+	}
+}
+
+func TestIllegalTransitions(t *testing.T) {
+	ctx := context.Background()
+
+	// Stopping a plugin that was never started
+	instance, plugin := newStubInstance("never-started")
+	err := instance.Stop(ctx)
+	var terr *TransitionError
+	if !errors.As(err, &terr) || terr.Op != "stop" || terr.From != StatusIdle || terr.To != StatusStopping {
+		t.Errorf("Stop of idle instance = %v", err)
+	}
+	if !errors.Is(err, ErrIllegalTransition) {
+		t.Errorf("Stop error %v is not ErrIllegalTransition", err)
+	}
+	if len(plugin.Calls()) != 0 {
+		t.Errorf("plugin called: %v", plugin.Calls())
+	}
+
+	// Starting a running plugin
+	if err := instance.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	err = instance.Start(ctx)
+	if !errors.Is(err, ErrStopRequired) {
+		t.Errorf("Start of running instance = %v, want ErrStopRequired", err)
+	}
+
+	// Reloading while another operation is in progress
+	instance.mu.Lock()
+	_ = instance.transitionLocked("stop", StatusStopping, "test", nil)
+	instance.mu.Unlock()
+	err = instance.UpdateConfig(&stubConfig{Value: "v2"})
+	if !errors.As(err, &terr) || terr.Op != "reload" || terr.From != StatusStopping {
+		t.Errorf("UpdateConfig while stopping = %v", err)
+	}
+	if cfg := instance.GetConfig().(*stubConfig); cfg.Value != "v1" {
+		t.Errorf("config = %q after a refused reload, want v1", cfg.Value)
+	}
+}
+
+func TestStartAfterFailureRequiresStop(t *testing.T) {
+	ctx := context.Background()
+	instance, plugin := newStubInstance("failed")
+	if err := instance.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	reportError(t, instance, plugin)
+
+	if err := instance.Start(ctx); !errors.Is(err, ErrStopRequired) {
+		t.Fatalf("Start in Error = %v, want ErrStopRequired", err)
+	}
+	if err := instance.Restart(ctx); err != nil {
+		t.Fatalf("Restart = %v", err)
+	}
+	if got, want := plugin.Calls(), []string{"start", "stop", "start"}; !equalStrings(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+
+	// A plugin whose Start failed can start again without being stopped
+	failing, failingPlugin := newStubInstance("start-failed")
+	failingPlugin.startErr = errors.New("port in use")
+	if err := failing.Start(ctx); err == nil || failing.Status() != StatusError {
+		t.Fatalf("Start = %v, status %s", err, failing.Status())
+	}
+	failingPlugin.startErr = nil
+	if err := failing.Start(ctx); err != nil {
+		t.Fatalf("second Start = %v", err)
+	}
+	if err := failing.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := failing.Stop(ctx); err != nil {
+		t.Errorf("second Stop = %v, want a no-op", err)
+	}
+	if got, want := failingPlugin.Calls(), []string{"start", "start", "stop"}; !equalStrings(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestRetire(t *testing.T) {
+	ctx := context.Background()
+	instance, plugin := newStubInstance("retired")
+	if err := instance.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := instance.Retire(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if plugin.Running() || instance.Status() != StatusStopped {
+		t.Errorf("status = %s after Retire", instance.Status())
+	}
+	if err := instance.Start(ctx); !errors.Is(err, ErrInstanceRetired) {
+		t.Errorf("Start = %v, want ErrInstanceRetired", err)
+	}
+	if err := instance.Restart(ctx); !errors.Is(err, ErrInstanceRetired) {
+		t.Errorf("Restart = %v, want ErrInstanceRetired", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sync/atomic"
 	"time"
 )
 
@@ -43,18 +44,19 @@ func (l LogLevel) String() string {
 
 // StandardLogger is the standard logger implementation.
 type StandardLogger struct {
-	level  LogLevel
+	level  atomic.Int32 // LogLevel, atomic so it can change while logging
 	logger *log.Logger
 	prefix string
 }
 
 // NewStandardLogger creates a new StandardLogger instance.
 func NewStandardLogger(prefix string, level LogLevel) *StandardLogger {
-	return &StandardLogger{
-		level:  level,
+	l := &StandardLogger{
 		logger: log.New(os.Stdout, "", 0),
 		prefix: prefix,
 	}
+	l.SetLevel(level)
+	return l
 }
 
 // NewDefaultLogger creates a default Logger instance (INFO level).
@@ -74,12 +76,17 @@ func (l *StandardLogger) formatMessage(level LogLevel, args ...interface{}) stri
 
 // shouldLog determines whether to output the log.
 func (l *StandardLogger) shouldLog(level LogLevel) bool {
-	return level >= l.level
+	return level >= l.Level()
 }
 
-// SetLevel sets the log level.
+// SetLevel sets the log level. Safe to call while logging.
 func (l *StandardLogger) SetLevel(level LogLevel) {
-	l.level = level
+	l.level.Store(int32(level))
+}
+
+// Level returns the current log level.
+func (l *StandardLogger) Level() LogLevel {
+	return LogLevel(l.level.Load())
 }
 
 // Trace outputs trace level log.
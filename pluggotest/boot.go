@@ -0,0 +1,89 @@
+package pluggotest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seencxy/plugGo"
+	"github.com/seencxy/plugGo/registry"
+)
+
+// bootShutdownTimeout bounds the shutdown of a test Boot.
+const bootShutdownTimeout = 10 * time.Second
+
+// NewBoot creates a Boot from raw boot.yaml content for a test.
+// The config is linted first (see registry.LintConfig) and problems in plugin
+// sections fail the test, as do instance names already in the global registry
+// (typically leaked by another test). When the test ends the Boot is shut down
+// and its instances and Entries are removed from the registry and GlobalAppCtx,
+// so later tests can reuse the same names.
+//
+// Parameters:
+//   - t: the test
+//   - raw: boot.yaml content, e.g. a YAML string literal
+//   - opts: extra Boot options (WithConfigRaw is set by the helper)
+//
+// Returns:
+//   - *plugGo.Boot: the Boot, not yet bootstrapped
+func NewBoot(t testing.TB, raw string, opts ...plugGo.BootOption) *plugGo.Boot {
+	t.Helper()
+
+	report := registry.LintConfig([]byte(raw))
+	var problems []string
+	for _, issue := range report.Issues {
+		if _, isPlugin := registry.GetFactory(issue.Section); isPlugin {
+			problems = append(problems, issue.String())
+		}
+	}
+	if len(problems) > 0 {
+		t.Fatalf("invalid boot config:\n%s", strings.Join(problems, "\n"))
+	}
+	for _, inst := range report.Instances {
+		if _, exists := registry.GetInstance(registry.EntryInstanceID(inst.PluginType, inst.Name)); exists {
+			t.Fatalf("instance %q already exists in the registry; was it left over by another test?", inst.Name)
+		}
+	}
+
+	boot := plugGo.NewBoot(append(opts, plugGo.WithConfigRaw([]byte(raw)))...)
+	t.Cleanup(func() { cleanupBoot(boot) })
+	return boot
+}
+
+// StartBoot creates a Boot with NewBoot and bootstraps it, failing the test if
+// an Entry doesn't bootstrap in time.
+func StartBoot(t testing.TB, raw string, opts ...plugGo.BootOption) *plugGo.Boot {
+	t.Helper()
+	boot := NewBoot(t, raw, opts...)
+	if err := boot.Bootstrap(context.Background()); err != nil {
+		t.Fatalf("Bootstrap: %v", err)
+	}
+	return boot
+}
+
+// Instance returns the plugin instance of a Boot's plugin Entry, failing the test if there is none.
+func Instance(t testing.TB, boot *plugGo.Boot, pluginType, name string) *plugGo.PluginInstance {
+	t.Helper()
+	entry, ok := boot.GetEntry(pluginType, name).(*plugGo.PluginEntry)
+	if !ok {
+		t.Fatalf("no %s plugin entry named %q", pluginType, name)
+	}
+	return entry.Instance()
+}
+
+// cleanupBoot shuts a Boot down and unregisters everything it created.
+func cleanupBoot(boot *plugGo.Boot) {
+	ctx, cancel := context.WithTimeout(context.Background(), bootShutdownTimeout)
+	defer cancel()
+	boot.Shutdown(ctx)
+
+	for entryType, byName := range boot.GetAllEntries() {
+		for name, entry := range byName {
+			if pluginEntry, ok := entry.(*plugGo.PluginEntry); ok {
+				_ = registry.RemoveInstance(pluginEntry.Instance().ID(), registry.WithForce())
+			}
+			plugGo.GlobalAppCtx.RemoveEntry(entryType, name)
+		}
+	}
+}
@@ -0,0 +1,409 @@
+package pluggotest
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seencxy/plugGo"
+)
+
+// Default limits of the conformance suite.
+const (
+	defaultOpTimeout   = 5 * time.Second
+	defaultDeadline    = 200 * time.Millisecond
+	defaultLeakTimeout = 2 * time.Second
+	deadlineGrace      = 500 * time.Millisecond
+)
+
+// ConformanceOption is a conformance suite configuration option function.
+type ConformanceOption func(*conformance)
+
+type conformance struct {
+	factory     plugGo.PluginFactory
+	newConfig   func() interface{}
+	opTimeout   time.Duration
+	deadline    time.Duration
+	leakTimeout time.Duration
+	leakCheck   bool
+}
+
+// WithConfig sets the function building the config of each created plugin.
+// It is called once per plugin so plugins never share a config; defaults to factory.DefaultConfig.
+func WithConfig(newConfig func() interface{}) ConformanceOption {
+	return func(c *conformance) {
+		c.newConfig = newConfig
+	}
+}
+
+// WithOpTimeout sets how long Start, Stop and Reload may take (default 5s).
+func WithOpTimeout(timeout time.Duration) ConformanceOption {
+	return func(c *conformance) {
+		c.opTimeout = timeout
+	}
+}
+
+// WithDeadline sets the context deadline given to Stop in the deadline check (default 200ms).
+// Stop must return within it plus a 500ms grace period.
+func WithDeadline(deadline time.Duration) ConformanceOption {
+	return func(c *conformance) {
+		c.deadline = deadline
+	}
+}
+
+// WithoutGoroutineCheck skips the goroutine leak check, e.g. for plugins using
+// an HTTP client whose idle connections outlive Stop.
+func WithoutGoroutineCheck() ConformanceOption {
+	return func(c *conformance) {
+		c.leakCheck = false
+	}
+}
+
+// RunConformance checks the lifecycle contracts plugGo relies on, as subtests of t:
+//   - the default config validates and config type mismatches are rejected
+//   - Start/Stop through a PluginInstance, restart after Stop, Stop and Start idempotency
+//   - Reload while running and while stopped
+//   - status events reported in order (Running before Stopped, no Error)
+//   - no goroutines left behind after Stop
+//   - Stop honours the context deadline
+//
+// Plugins are created directly through the factory, not in the global registry,
+// so the suite doesn't interfere with other tests.
+func RunConformance(t *testing.T, factory plugGo.PluginFactory, opts ...ConformanceOption) {
+	c := &conformance{
+		factory:     factory,
+		newConfig:   factory.DefaultConfig,
+		opTimeout:   defaultOpTimeout,
+		deadline:    defaultDeadline,
+		leakTimeout: defaultLeakTimeout,
+		leakCheck:   true,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	t.Run("Config", c.testConfig)
+	t.Run("Metadata", c.testMetadata)
+	t.Run("StartStop", c.testStartStop)
+	t.Run("StopIdempotent", c.testStopIdempotent)
+	t.Run("StartTwice", c.testStartTwice)
+	t.Run("ReloadWhileRunning", c.testReloadWhileRunning)
+	t.Run("ReloadWhileStopped", c.testReloadWhileStopped)
+	t.Run("StatusEvents", c.testStatusEvents)
+	t.Run("StopDeadline", c.testStopDeadline)
+	if c.leakCheck {
+		t.Run("NoGoroutineLeak", c.testNoGoroutineLeak)
+	}
+}
+
+func (c *conformance) testConfig(t *testing.T) {
+	if err := c.factory.ValidateConfig(c.newConfig()); err != nil {
+		t.Fatalf("ValidateConfig(config) = %v, want nil", err)
+	}
+
+	type wrongType struct{}
+	if err := c.factory.ValidateConfig(&wrongType{}); err == nil {
+		t.Errorf("ValidateConfig accepted a config of the wrong type")
+	}
+	if _, err := c.factory.Create("conformance", &wrongType{}, NewLogger()); err == nil {
+		t.Errorf("Create accepted a config of the wrong type")
+	}
+}
+
+func (c *conformance) testMetadata(t *testing.T) {
+	p := c.newPlugin(t, "conformance")
+	if p.ID() != "conformance" {
+		t.Errorf("ID() = %q, want %q", p.ID(), "conformance")
+	}
+	if p.PluginType() != c.factory.Name() {
+		t.Errorf("PluginType() = %q, want factory name %q", p.PluginType(), c.factory.Name())
+	}
+	if p.Version() == "" {
+		t.Errorf("Version() is empty")
+	}
+	if p.StatusNotify() == nil {
+		t.Errorf("StatusNotify() returned nil")
+	}
+}
+
+func (c *conformance) testStartStop(t *testing.T) {
+	instance := c.newInstance(t)
+
+	for round := 1; round <= 2; round++ {
+		c.start(t, instance)
+		c.expectStatus(t, instance, plugGo.StatusRunning)
+		c.stop(t, instance)
+		c.expectStatus(t, instance, plugGo.StatusStopped)
+	}
+}
+
+func (c *conformance) testStopIdempotent(t *testing.T) {
+	p := c.newPlugin(t, "conformance")
+
+	if err := p.Stop(c.ctx(t)); err != nil {
+		t.Errorf("Stop before Start = %v, want nil", err)
+	}
+	if err := p.Start(c.ctx(t)); err != nil {
+		t.Fatalf("Start = %v", err)
+	}
+	if err := p.Stop(c.ctx(t)); err != nil {
+		t.Fatalf("Stop = %v", err)
+	}
+	if err := p.Stop(c.ctx(t)); err != nil {
+		t.Errorf("second Stop = %v, want nil", err)
+	}
+}
+
+func (c *conformance) testStartTwice(t *testing.T) {
+	p := c.newPlugin(t, "conformance")
+
+	if err := p.Start(c.ctx(t)); err != nil {
+		t.Fatalf("Start = %v", err)
+	}
+	// A second Start may fail or be a no-op, but must leave one running plugin that stops cleanly
+	_ = p.Start(c.ctx(t))
+	if err := p.Stop(c.ctx(t)); err != nil {
+		t.Fatalf("Stop after second Start = %v", err)
+	}
+	if status := p.Status(); status == plugGo.StatusRunning {
+		t.Errorf("plugin status after Stop = %s", status)
+	}
+}
+
+func (c *conformance) testReloadWhileRunning(t *testing.T) {
+	instance := c.newInstance(t)
+	c.start(t, instance)
+
+	cfg := c.newConfig()
+	if err := c.withTimeout(t, "UpdateConfig", func(ctx context.Context) error {
+		return instance.UpdateConfigWithContext(ctx, cfg)
+	}); err != nil {
+		t.Fatalf("UpdateConfig while running = %v", err)
+	}
+	c.expectStatus(t, instance, plugGo.StatusRunning)
+	if instance.GetConfig() != cfg {
+		t.Errorf("GetConfig() doesn't return the reloaded config")
+	}
+
+	c.stop(t, instance)
+}
+
+func (c *conformance) testReloadWhileStopped(t *testing.T) {
+	instance := c.newInstance(t)
+	c.start(t, instance)
+	c.stop(t, instance)
+
+	cfg := c.newConfig()
+	if err := c.withTimeout(t, "UpdateConfig", func(ctx context.Context) error {
+		return instance.UpdateConfigWithContext(ctx, cfg)
+	}); err != nil {
+		t.Fatalf("UpdateConfig while stopped = %v", err)
+	}
+	c.expectStatus(t, instance, plugGo.StatusStopped)
+
+	c.start(t, instance)
+	c.expectStatus(t, instance, plugGo.StatusRunning)
+	c.stop(t, instance)
+}
+
+func (c *conformance) testStatusEvents(t *testing.T) {
+	// The plugin's own events, read directly since no PluginInstance consumes them here
+	p := c.newPlugin(t, "conformance")
+	if err := p.Start(c.ctx(t)); err != nil {
+		t.Fatalf("Start = %v", err)
+	}
+	if err := p.Stop(c.ctx(t)); err != nil {
+		t.Fatalf("Stop = %v", err)
+	}
+	events := drain(p.StatusNotify())
+	running, stopped := -1, -1
+	for i, event := range events {
+		switch event.Status {
+		case plugGo.StatusError:
+			t.Errorf("plugin reported an error during Start/Stop: %v", event.Error)
+		case plugGo.StatusRunning:
+			if running < 0 {
+				running = i
+			}
+		case plugGo.StatusStopped:
+			stopped = i
+		}
+	}
+	if len(events) > 0 {
+		if last := events[len(events)-1].Status; last != plugGo.StatusStopped {
+			t.Errorf("last plugin event after Stop = %s, want %s (events: %s)", last, plugGo.StatusStopped, statuses(events))
+		}
+		if running >= 0 && stopped >= 0 && stopped < running {
+			t.Errorf("plugin reported Stopped before Running (events: %s)", statuses(events))
+		}
+	}
+
+	// Transitions seen by instance subscribers
+	instance := c.newInstance(t)
+	sub := instance.Subscribe(32)
+	defer instance.Unsubscribe(sub)
+	c.start(t, instance)
+	c.stop(t, instance)
+
+	want := []plugGo.PluginStatus{plugGo.StatusIdle, plugGo.StatusStarting, plugGo.StatusRunning, plugGo.StatusStopping, plugGo.StatusStopped}
+	var got []plugGo.StatusEvent
+	timeout := time.After(c.opTimeout)
+	for len(got) < len(want) {
+		select {
+		case event := <-sub.Events():
+			got = append(got, event)
+		case <-timeout:
+			t.Fatalf("instance events = %s, want %v", statuses(got), want)
+		}
+	}
+	for i, event := range got {
+		if event.Status != want[i] {
+			t.Fatalf("instance events = %s, want %v", statuses(got), want)
+		}
+	}
+}
+
+func (c *conformance) testStopDeadline(t *testing.T) {
+	instance := c.newInstance(t)
+	c.start(t, instance)
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.deadline)
+	defer cancel()
+	begin := time.Now()
+	err := instance.Stop(ctx)
+	if elapsed := time.Since(begin); elapsed > c.deadline+deadlineGrace {
+		t.Errorf("Stop took %v with a %v deadline", elapsed, c.deadline)
+	}
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Stop with deadline = %v", err)
+	}
+}
+
+func (c *conformance) testNoGoroutineLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	instance := c.newInstance(t)
+	c.start(t, instance)
+	if err := c.withTimeout(t, "UpdateConfig", func(ctx context.Context) error {
+		return instance.UpdateConfigWithContext(ctx, c.newConfig())
+	}); err != nil {
+		t.Fatalf("UpdateConfig = %v", err)
+	}
+	c.stop(t, instance)
+
+	deadline := time.Now().Add(c.leakTimeout)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			buf := make([]byte, 1<<20)
+			buf = buf[:runtime.Stack(buf, true)]
+			t.Fatalf("%d goroutines before Start, %d after Stop:\n%s", before, runtime.NumGoroutine(), buf)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// newPlugin creates a plugin with a fresh config and a recording logger,
+// stopped when the test ends.
+func (c *conformance) newPlugin(t *testing.T, id string) plugGo.Plugin {
+	t.Helper()
+	p, err := c.factory.Create(id, c.newConfig(), NewLogger())
+	if err != nil {
+		t.Fatalf("Create = %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.opTimeout)
+		defer cancel()
+		_ = p.Stop(ctx)
+	})
+	return p
+}
+
+// newInstance wraps a new plugin in a PluginInstance outside the global registry.
+func (c *conformance) newInstance(t *testing.T) *plugGo.PluginInstance {
+	t.Helper()
+	cfg := c.newConfig()
+	p, err := c.factory.Create("conformance", cfg, NewLogger())
+	if err != nil {
+		t.Fatalf("Create = %v", err)
+	}
+	instance := plugGo.NewPluginInstance("conformance", c.factory.Name(), p, cfg, c.factory)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.opTimeout)
+		defer cancel()
+		_ = instance.Stop(ctx)
+		instance.UnsubscribeAll()
+	})
+	return instance
+}
+
+func (c *conformance) start(t *testing.T, instance *plugGo.PluginInstance) {
+	t.Helper()
+	if err := c.withTimeout(t, "Start", instance.Start); err != nil {
+		t.Fatalf("Start = %v", err)
+	}
+}
+
+func (c *conformance) stop(t *testing.T, instance *plugGo.PluginInstance) {
+	t.Helper()
+	if err := c.withTimeout(t, "Stop", instance.Stop); err != nil {
+		t.Fatalf("Stop = %v", err)
+	}
+}
+
+func (c *conformance) expectStatus(t *testing.T, instance *plugGo.PluginInstance, want plugGo.PluginStatus) {
+	t.Helper()
+	if status := instance.Status(); status != want {
+		t.Fatalf("status = %s, want %s (last transition: %s)", status, want, instance.LastTransition().Reason)
+	}
+}
+
+// ctx returns a context bounded by the operation timeout.
+func (c *conformance) ctx(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), c.opTimeout)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+// withTimeout runs op with a bounded context and fails the test if it doesn't return in time.
+func (c *conformance) withTimeout(t *testing.T, name string, op func(ctx context.Context) error) error {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), c.opTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- op(ctx) }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(c.opTimeout + deadlineGrace):
+		t.Fatalf("%s didn't return within %v", name, c.opTimeout)
+		return nil
+	}
+}
+
+// drain returns the events buffered in ch without blocking.
+func drain(ch <-chan plugGo.StatusEvent) []plugGo.StatusEvent {
+	var events []plugGo.StatusEvent
+	for {
+		select {
+		case event, ok := <-ch:
+			if !ok {
+				return events
+			}
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func statuses(events []plugGo.StatusEvent) string {
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = event.Status.String()
+	}
+	return "[" + strings.Join(names, " ") + "]"
+}
@@ -0,0 +1,110 @@
+// Package pluggotest helps plugin authors test plugins: a recording Logger,
+// a lifecycle conformance suite for any PluginFactory, and helpers to boot
+// a Boot from raw config in isolation.
+//
+//	func TestConformance(t *testing.T) {
+//		pluggotest.RunConformance(t, yourplugin.NewFactory())
+//	}
+package pluggotest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seencxy/plugGo"
+)
+
+// LogEntry is one recorded log call.
+type LogEntry struct {
+	Level   plugGo.LogLevel
+	Message string // Arguments formatted with fmt.Sprint, like StandardLogger
+	Time    time.Time
+}
+
+// String formats the entry as "[LEVEL] message".
+func (e LogEntry) String() string {
+	return fmt.Sprintf("[%s] %s", e.Level, e.Message)
+}
+
+// Logger is a plugGo.Logger recording every call in memory.
+// It is safe for concurrent use, so plugins can log from their goroutines.
+type Logger struct {
+	entries []LogEntry
+	mu      sync.Mutex
+}
+
+// NewLogger creates an empty recording logger.
+func NewLogger() *Logger {
+	return &Logger{}
+}
+
+func (l *Logger) record(level plugGo.LogLevel, args []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, LogEntry{Level: level, Message: fmt.Sprint(args...), Time: time.Now()})
+}
+
+// Trace records a trace level message.
+func (l *Logger) Trace(args ...interface{}) { l.record(plugGo.TraceLevel, args) }
+
+// Debug records a debug level message.
+func (l *Logger) Debug(args ...interface{}) { l.record(plugGo.DebugLevel, args) }
+
+// Info records an info level message.
+func (l *Logger) Info(args ...interface{}) { l.record(plugGo.InfoLevel, args) }
+
+// Warn records a warn level message.
+func (l *Logger) Warn(args ...interface{}) { l.record(plugGo.WarnLevel, args) }
+
+// Error records an error level message.
+func (l *Logger) Error(args ...interface{}) { l.record(plugGo.ErrorLevel, args) }
+
+// Entries returns a copy of the recorded entries, oldest first.
+func (l *Logger) Entries() []LogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]LogEntry(nil), l.entries...)
+}
+
+// Messages returns the messages recorded at level, oldest first.
+func (l *Logger) Messages(level plugGo.LogLevel) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var result []string
+	for _, e := range l.entries {
+		if e.Level == level {
+			result = append(result, e.Message)
+		}
+	}
+	return result
+}
+
+// Contains reports whether a message containing substr was recorded at level.
+func (l *Logger) Contains(level plugGo.LogLevel, substr string) bool {
+	for _, message := range l.Messages(level) {
+		if strings.Contains(message, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reset discards the recorded entries.
+func (l *Logger) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = nil
+}
+
+// String returns all entries, one per line, e.g. to include in a failure message.
+func (l *Logger) String() string {
+	var b strings.Builder
+	for _, e := range l.Entries() {
+		b.WriteString(e.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -1,5 +1,12 @@
 package plugGo
 
+import (
+	"context"
+	"time"
+
+	"github.com/seencxy/plugGo/config"
+)
+
 // Plugin defines the standard interface for plugins.
 // Plugin instances focus on business logic, config management is handled by framework and factory.
 type Plugin interface {
@@ -23,3 +30,65 @@ type Plugin interface {
 	// Note: This method may restart internal components to apply new config.
 	Reload(config interface{}) error
 }
+
+// DiffReloader is an optional interface for plugins that apply config changes incrementally.
+// When a running plugin implements it, PluginInstance calls ReloadWithDiff instead of Reload,
+// passing the structural difference from the current config (see config.Compare).
+// Fields tagged `reload:"hot"` can typically be applied without restarting components.
+type DiffReloader interface {
+	// ReloadWithDiff reloads the plugin with new config.
+	// Parameters:
+	//   - cfg: new config object (already validated)
+	//   - diff: changes from the previous config
+	// Returns:
+	//   - error: returns error if reload fails
+	ReloadWithDiff(cfg interface{}, diff config.Diff) error
+}
+
+// HealthReporter is an optional interface for plugins whose components can fail
+// independently while the plugin keeps running (e.g. one polled source out of many).
+// PluginInstance.Health exposes it next to the lifecycle status.
+type HealthReporter interface {
+	// Health returns the current health of each component.
+	Health() []ComponentHealth
+}
+
+// Drainer is an optional interface for plugins and Entries that finish pending work
+// before they are stopped, e.g. flush queued notifications. During shutdown Boot
+// reports not ready, waits the drain period, then calls Drain before any Interrupt.
+// Drain must not stop the plugin: it keeps running until Stop.
+type Drainer interface {
+	// Drain completes pending work, returning early with an error when ctx is done.
+	Drain(ctx context.Context) error
+}
+
+// ComponentHealth is the health of one component of a plugin.
+type ComponentHealth struct {
+	Name                string            `json:"name"`                // Component name, e.g. a source name
+	Healthy             bool              `json:"healthy"`             // False while the component is failing
+	LastCheck           time.Time         `json:"lastCheck"`           // Last time the component was exercised
+	LastSuccess         time.Time         `json:"lastSuccess"`         // Last time it succeeded (zero if never)
+	LastError           string            `json:"lastError,omitempty"` // Error of the last failure
+	ConsecutiveFailures int               `json:"consecutiveFailures"` // Failures since the last success
+	Details             map[string]string `json:"details,omitempty"`   // Plugin-specific details
+}
+
+// ScheduledPlugin is an optional interface for plugins with periodic work.
+// PluginInstance runs the jobs while the plugin is running: they are scheduled
+// after Start and after every Reload, and cancelled before Stop and Reload,
+// so jobs always see the current config and never outlive the plugin.
+type ScheduledPlugin interface {
+	// ScheduledJobs returns the jobs to run with the current config.
+	ScheduledJobs() []ScheduledJob
+}
+
+// ScheduledJob is a job run by PluginInstance on a cron schedule.
+type ScheduledJob struct {
+	// Name identifies the job in logs (unique per plugin).
+	Name string
+	// Schedule is a cron expression or shortcut, e.g. "*/5 * * * *", "@hourly"
+	// or "@every 30s" (see package schedule).
+	Schedule string
+	// Run does the work; ctx is cancelled when the job is cancelled.
+	Run func(ctx context.Context) error
+}
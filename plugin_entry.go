@@ -0,0 +1,215 @@
+package plugGo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/seencxy/plugGo/config"
+)
+
+// InstanceMeta holds the framework-level keys shared by every instance in a boot.yaml section.
+// Plugin-specific keys are parsed by the factory's config struct.
+//
+//	announcement:
+//	  - name: "official"   # instance name (defaults to "<plugin>-<index>")
+//	    enabled: true      # disabled instances are created but not started
+//	    logLevel: "info"   # level of the instance logger
+//	    bootstrapTimeout: 2m  # overrides the Boot's EntryBootstrapTimeout
+//	    bootstrapOrder: 1     # starts after instances of lower order (default 0)
+//	    labels:               # for registry selectors, e.g. "team=payments"
+//	      team: payments
+type InstanceMeta struct {
+	Name             string            `yaml:"name"`
+	Enabled          *bool             `yaml:"enabled"`
+	LogLevel         string            `yaml:"logLevel"`
+	BootstrapTimeout time.Duration     `yaml:"bootstrapTimeout"`
+	BootstrapOrder   int               `yaml:"bootstrapOrder"`
+	Labels           map[string]string `yaml:"labels"`
+}
+
+// IsEnabled reports whether the instance should be started (enabled unless set to false).
+func (m InstanceMeta) IsEnabled() bool {
+	return m.Enabled == nil || *m.Enabled
+}
+
+// PluginEntry adapts a factory-built PluginInstance to the Entry interface,
+// so plugins only need a factory and a plugin to be managed by Boot.
+type PluginEntry struct {
+	name             string
+	instance         *PluginInstance
+	enabled          bool
+	bootstrapTimeout time.Duration
+	bootstrapOrder   int
+	onRemove         func() error // Releases the instance when the Entry is removed
+}
+
+// NewPluginEntry wraps a plugin instance as an Entry.
+// The Entry type is the plugin type, disabled Entries are never started.
+func NewPluginEntry(name string, instance *PluginInstance, enabled bool) *PluginEntry {
+	return &PluginEntry{
+		name:     name,
+		instance: instance,
+		enabled:  enabled,
+	}
+}
+
+// Bootstrap starts the plugin instance.
+func (e *PluginEntry) Bootstrap(ctx context.Context) {
+	_ = e.BootstrapWithError(ctx)
+}
+
+// BootstrapWithError starts the plugin instance and returns why it failed to start
+// (see BootstrapErrorEntry). Disabled Entries are skipped.
+func (e *PluginEntry) BootstrapWithError(ctx context.Context) error {
+	logger := e.instance.GetLogger()
+	if !e.enabled {
+		logger.Info(fmt.Sprintf("[%s] Entry is disabled, skipping bootstrap", e.name))
+		return nil
+	}
+
+	if err := e.instance.Start(ctx); err != nil {
+		logger.Error(fmt.Sprintf("[%s] Failed to start: %v", e.name, err))
+		return fmt.Errorf("start: %w", err)
+	}
+	logger.Info(fmt.Sprintf("[%s] Bootstrapped successfully", e.name))
+	return nil
+}
+
+// Interrupt stops the plugin instance if it was started.
+func (e *PluginEntry) Interrupt(ctx context.Context) {
+	_ = e.InterruptWithError(ctx)
+}
+
+// InterruptWithError stops the plugin instance if it was started and returns why
+// it failed to stop (see InterruptErrorEntry).
+func (e *PluginEntry) InterruptWithError(ctx context.Context) error {
+	if e.instance.Status() == StatusIdle {
+		return nil
+	}
+
+	logger := e.instance.GetLogger()
+	if err := e.instance.Stop(ctx); err != nil {
+		logger.Error(fmt.Sprintf("[%s] Failed to stop: %v", e.name, err))
+		return fmt.Errorf("stop: %w", err)
+	}
+	logger.Info(fmt.Sprintf("[%s] Interrupted", e.name))
+	return nil
+}
+
+// Drain lets the plugin finish pending work before Interrupt (see Drainer).
+func (e *PluginEntry) Drain(ctx context.Context) error {
+	return e.instance.Drain(ctx)
+}
+
+// GetName returns the instance name.
+func (e *PluginEntry) GetName() string {
+	return e.name
+}
+
+// GetType returns the plugin type.
+func (e *PluginEntry) GetType() string {
+	return e.instance.PluginType()
+}
+
+// GetDescription returns the description.
+func (e *PluginEntry) GetDescription() string {
+	return fmt.Sprintf("%s plugin v%s [%s]", e.instance.PluginType(), e.instance.Plugin().Version(), e.name)
+}
+
+// String returns string representation.
+func (e *PluginEntry) String() string {
+	if labels := e.instance.Labels(); len(labels) > 0 {
+		return fmt.Sprintf("PluginEntry{type=%s, name=%s, enabled=%v, status=%s, labels=%v}",
+			e.instance.PluginType(), e.name, e.enabled, e.instance.Status(), labels)
+	}
+	return fmt.Sprintf("PluginEntry{type=%s, name=%s, enabled=%v, status=%s}",
+		e.instance.PluginType(), e.name, e.enabled, e.instance.Status())
+}
+
+// Enabled reports whether the Entry is started by Bootstrap.
+func (e *PluginEntry) Enabled() bool {
+	return e.enabled
+}
+
+// SetBootstrapTimeout overrides the Boot's bootstrap timeout for this Entry (0 keeps the Boot's).
+func (e *PluginEntry) SetBootstrapTimeout(timeout time.Duration) {
+	e.bootstrapTimeout = timeout
+}
+
+// BootstrapTimeout returns the Entry's own bootstrap timeout (0 if it uses the Boot's).
+func (e *PluginEntry) BootstrapTimeout() time.Duration {
+	return e.bootstrapTimeout
+}
+
+// SetBootstrapOrder sets the order of this Entry among plugin Entries (see BootstrapOrderEntry).
+func (e *PluginEntry) SetBootstrapOrder(order int) {
+	e.bootstrapOrder = order
+}
+
+// BootstrapOrder returns the order of this Entry among plugin Entries.
+func (e *PluginEntry) BootstrapOrder() int {
+	return e.bootstrapOrder
+}
+
+// SetOnRemove sets the function releasing the instance when the Entry is removed
+// from a Boot, e.g. unregistering it from the registry (see RemovableEntry).
+func (e *PluginEntry) SetOnRemove(f func() error) {
+	e.onRemove = f
+}
+
+// Remove releases the instance (see SetOnRemove).
+func (e *PluginEntry) Remove() error {
+	if e.onRemove == nil {
+		return nil
+	}
+	return e.onRemove()
+}
+
+// Instance returns the underlying plugin instance.
+func (e *PluginEntry) Instance() *PluginInstance {
+	return e.instance
+}
+
+// ReloadConfig applies the Entry's element of a boot.yaml to the plugin instance
+// (see ReloadConfigEntry). The element is found by instance name and parsed into the
+// factory's DefaultConfig like at creation; an unchanged config is not reloaded.
+func (e *PluginEntry) ReloadConfig(ctx context.Context, raw []byte) error {
+	cfg, err := e.configFromYAML(raw)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(cfg, e.instance.GetConfig()) {
+		return nil
+	}
+	return e.instance.UpdateConfigWithContext(ctx, cfg)
+}
+
+// configFromYAML parses the Entry's element of the plugin type's boot.yaml section.
+func (e *PluginEntry) configFromYAML(raw []byte) (interface{}, error) {
+	pluginType := e.instance.PluginType()
+	items, err := config.GetYAMLSectionItems(raw, pluginType)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range items {
+		var meta InstanceMeta
+		if err := config.UnmarshalYAML(item, &meta); err != nil {
+			return nil, fmt.Errorf("%s[%d]: %w", pluginType, i, err)
+		}
+		name := meta.Name
+		if name == "" {
+			name = fmt.Sprintf("%s-%d", pluginType, i)
+		}
+		if name != e.name {
+			continue
+		}
+		cfg := e.instance.factory.DefaultConfig()
+		if err := config.UnmarshalYAML(item, cfg); err != nil {
+			return nil, fmt.Errorf("%s[%d] %s: %w", pluginType, i, name, err)
+		}
+		return cfg, nil
+	}
+	return nil, fmt.Errorf("instance %s not found in the %s section", e.name, pluginType)
+}
@@ -0,0 +1,357 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/seencxy/plugGo"
+)
+
+// ErrInstanceActive is returned by RemoveInstance for an instance that is still
+// starting, running, reloading or stopping. Stop it first, use StopAndRemove, or
+// pass WithForce to orphan it deliberately.
+var ErrInstanceActive = errors.New("instance is active")
+
+// defaultBulkConcurrency is the number of instances bulk operations handle at once.
+const defaultBulkConcurrency = 8
+
+// defaultBulkTimeout is the per-instance timeout of bulk operations.
+const defaultBulkTimeout = 10 * time.Second
+
+// shutdownHookName is the name of the registry's GlobalAppCtx shutdown hook.
+const shutdownHookName = "registry"
+
+// InstallShutdownHook registers Shutdown as the "registry" GlobalAppCtx shutdown hook,
+// run after Boot interrupted its Entries, so Boot.Shutdown also stops the instances
+// Boot doesn't manage (created with CreateInstance). Installing it again has no effect.
+func InstallShutdownHook() {
+	plugGo.GlobalAppCtx.AddShutdownHookWithContext(shutdownHookName, 0, Shutdown, plugGo.WithShutdownHookAfterEntries())
+}
+
+// UninstallShutdownHook removes the hook added by InstallShutdownHook.
+func UninstallShutdownHook() {
+	plugGo.GlobalAppCtx.RemoveShutdownHook(shutdownHookName)
+}
+
+// removeOptions holds RemoveInstance options.
+type removeOptions struct {
+	force bool
+}
+
+// RemoveOption is a RemoveInstance option function.
+type RemoveOption func(*removeOptions)
+
+// WithForce removes an instance even while it is active, leaving its plugin running.
+func WithForce() RemoveOption {
+	return func(o *removeOptions) {
+		o.force = true
+	}
+}
+
+// bulkOptions holds options of operations on many instances.
+type bulkOptions struct {
+	concurrency     int
+	timeout         time.Duration
+	includeDisabled bool
+}
+
+// BulkOption is an option function of operations on many instances.
+type BulkOption func(*bulkOptions)
+
+// WithConcurrency sets how many instances are handled at once (default 8).
+func WithConcurrency(n int) BulkOption {
+	return func(o *bulkOptions) {
+		o.concurrency = n
+	}
+}
+
+// WithInstanceTimeout sets the timeout of the operation on each instance (default 10s).
+func WithInstanceTimeout(timeout time.Duration) BulkOption {
+	return func(o *bulkOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithIncludeDisabled makes StartAll and StartSelected also start instances
+// disabled in boot.yaml (see WithEnabled).
+func WithIncludeDisabled() BulkOption {
+	return func(o *bulkOptions) {
+		o.includeDisabled = true
+	}
+}
+
+// newBulkOptions applies opts to the defaults.
+func newBulkOptions(opts []BulkOption) bulkOptions {
+	o := bulkOptions{concurrency: defaultBulkConcurrency, timeout: defaultBulkTimeout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.concurrency < 1 {
+		o.concurrency = 1
+	}
+	return o
+}
+
+// isActive reports whether an instance must be stopped before it is removed.
+func isActive(status plugGo.PluginStatus) bool {
+	switch status {
+	case plugGo.StatusStarting, plugGo.StatusRunning, plugGo.StatusReloading, plugGo.StatusStopping:
+		return true
+	default:
+		return false
+	}
+}
+
+// StopAndRemove stops an instance, if needed, and removes it from the registry.
+// The instance is retired first (see plugGo.PluginInstance.Retire), so a concurrent
+// Start fails instead of leaving a running instance unregistered.
+// The instance stays registered if it fails to stop.
+//
+// Parameters:
+//   - ctx: context for Stop, bounding the plugin's graceful shutdown
+//   - instanceID: unique identifier of the instance
+//
+// Returns:
+//   - error: returns error if instance does not exist or fails to stop
+func StopAndRemove(ctx context.Context, instanceID string) error {
+	instance, ok := GetInstance(instanceID)
+	if !ok {
+		return fmt.Errorf("instance not found: %s", instanceID)
+	}
+	if err := instance.Retire(ctx); err != nil {
+		return fmt.Errorf("failed to stop instance %s: %w", instanceID, err)
+	}
+
+	defaultRegistry.mu.Lock()
+	defer defaultRegistry.mu.Unlock()
+	if defaultRegistry.instances[instanceID] != instance {
+		return fmt.Errorf("instance %s was removed concurrently", instanceID)
+	}
+	defaultRegistry.remove(instance)
+	return nil
+}
+
+// StartAll starts every registered instance that is not running, up to
+// WithConcurrency at a time. Instances disabled in boot.yaml are skipped unless
+// WithIncludeDisabled is given.
+//
+// Parameters:
+//   - ctx: context for the operation; each Start gets WithInstanceTimeout
+//   - opts: WithConcurrency, WithInstanceTimeout, WithIncludeDisabled
+//
+// Returns:
+//   - []plugGo.EntryResult: outcome per started instance, sorted by ID
+//   - error: joined errors of the instances that failed or timed out
+func StartAll(ctx context.Context, opts ...BulkOption) ([]plugGo.EntryResult, error) {
+	return startInstances(ctx, GetAllInstances(), opts)
+}
+
+// StopAll stops every registered instance that is not idle or stopped,
+// up to WithConcurrency at a time. Instances stay registered.
+//
+// Parameters:
+//   - ctx: context for the operation; each Stop gets WithInstanceTimeout
+//   - opts: WithConcurrency, WithInstanceTimeout
+//
+// Returns:
+//   - []plugGo.EntryResult: outcome per stopped instance, sorted by ID
+//   - error: joined errors of the instances that failed or timed out
+func StopAll(ctx context.Context, opts ...BulkOption) ([]plugGo.EntryResult, error) {
+	return stopInstances(ctx, GetAllInstances(), opts)
+}
+
+// Shutdown stops every instance still active, e.g. instances created with
+// CreateInstance outside Boot. With InstallShutdownHook it runs as a GlobalAppCtx
+// shutdown hook after Boot interrupted its Entries, so Boot.Shutdown tears the
+// registry down too. Instances stay registered, keeping their history.
+func Shutdown(ctx context.Context) error {
+	results, err := StopAll(ctx)
+	if len(results) > 0 {
+		logger.Info(fmt.Sprintf("Stopped %d remaining instances", len(results)))
+	}
+	return err
+}
+
+// StartSelected is StartAll limited to the instances matching a label selector
+// (see ParseSelector), e.g. "team=payments,env!=dev". Disabled instances are
+// skipped unless WithIncludeDisabled is given.
+//
+// Returns:
+//   - []plugGo.EntryResult: outcome per started instance, sorted by ID
+//   - error: returns error if the selector is malformed, or the joined errors of
+//     the instances that failed or timed out
+func StartSelected(ctx context.Context, selector string, opts ...BulkOption) ([]plugGo.EntryResult, error) {
+	instances, err := SelectInstances(selector)
+	if err != nil {
+		return nil, err
+	}
+	return startInstances(ctx, instances, opts)
+}
+
+// StopSelected is StopAll limited to the instances matching a label selector.
+//
+// Returns:
+//   - []plugGo.EntryResult: outcome per stopped instance, sorted by ID
+//   - error: returns error if the selector is malformed, or the joined errors of
+//     the instances that failed or timed out
+func StopSelected(ctx context.Context, selector string, opts ...BulkOption) ([]plugGo.EntryResult, error) {
+	instances, err := SelectInstances(selector)
+	if err != nil {
+		return nil, err
+	}
+	return stopInstances(ctx, instances, opts)
+}
+
+// RestartSelected restarts every instance matching a label selector; instances
+// that are not running are started.
+//
+// Returns:
+//   - []plugGo.EntryResult: outcome per instance, sorted by ID
+//   - error: returns error if the selector is malformed, or the joined errors of
+//     the instances that failed or timed out
+func RestartSelected(ctx context.Context, selector string, opts ...BulkOption) ([]plugGo.EntryResult, error) {
+	instances, err := SelectInstances(selector)
+	if err != nil {
+		return nil, err
+	}
+	return runBulk(ctx, instances, func(ctx context.Context, instance *plugGo.PluginInstance) error {
+		return instance.Restart(ctx)
+	}, opts)
+}
+
+// ReloadSelected reloads every instance matching a label selector with the config
+// update returns for it. Selected instances may be of different plugin types, so
+// update receives each instance and builds a config of its type, typically a
+// modified copy of instance.GetConfig().
+//
+// Parameters:
+//   - ctx: context for the operation, passed to the reload hooks
+//   - selector: label selector, e.g. "team=payments"
+//   - update: returns the new config of an instance; an error fails it without reloading
+//   - opts: WithConcurrency, WithInstanceTimeout
+//
+// Returns:
+//   - []plugGo.EntryResult: outcome per instance, sorted by ID
+//   - error: returns error if the selector is malformed, or the joined errors of
+//     the instances that failed or timed out
+func ReloadSelected(ctx context.Context, selector string, update func(instance *plugGo.PluginInstance) (interface{}, error), opts ...BulkOption) ([]plugGo.EntryResult, error) {
+	instances, err := SelectInstances(selector)
+	if err != nil {
+		return nil, err
+	}
+	return runBulk(ctx, instances, func(ctx context.Context, instance *plugGo.PluginInstance) error {
+		newConfig, err := update(instance)
+		if err != nil {
+			return fmt.Errorf("build config: %w", err)
+		}
+		return instance.UpdateConfigWithContext(ctx, newConfig)
+	}, opts)
+}
+
+// startInstances starts the instances that are not running, skipping disabled
+// ones unless WithIncludeDisabled is given.
+func startInstances(ctx context.Context, instances []*plugGo.PluginInstance, opts []BulkOption) ([]plugGo.EntryResult, error) {
+	o := newBulkOptions(opts)
+	defaultRegistry.mu.RLock()
+	disabled := make(map[string]bool, len(defaultRegistry.disabled))
+	for id := range defaultRegistry.disabled {
+		disabled[id] = true
+	}
+	defaultRegistry.mu.RUnlock()
+
+	var selected []*plugGo.PluginInstance
+	for _, instance := range instances {
+		if disabled[instance.ID()] && !o.includeDisabled {
+			continue
+		}
+		switch instance.Status() {
+		case plugGo.StatusIdle, plugGo.StatusStopped, plugGo.StatusError:
+			selected = append(selected, instance)
+		}
+	}
+	return runBulk(ctx, selected, func(ctx context.Context, instance *plugGo.PluginInstance) error {
+		return instance.Start(ctx)
+	}, opts)
+}
+
+// stopInstances stops the instances that are not idle or stopped.
+func stopInstances(ctx context.Context, instances []*plugGo.PluginInstance, opts []BulkOption) ([]plugGo.EntryResult, error) {
+	var selected []*plugGo.PluginInstance
+	for _, instance := range instances {
+		if status := instance.Status(); status != plugGo.StatusIdle && status != plugGo.StatusStopped {
+			selected = append(selected, instance)
+		}
+	}
+	return runBulk(ctx, selected, func(ctx context.Context, instance *plugGo.PluginInstance) error {
+		return instance.Stop(ctx)
+	}, opts)
+}
+
+// runBulk runs an operation on instances concurrently, each with its own timeout.
+// An instance whose operation doesn't return in time is reported as a timeout and abandoned.
+func runBulk(ctx context.Context, instances []*plugGo.PluginInstance, op func(context.Context, *plugGo.PluginInstance) error, opts []BulkOption) ([]plugGo.EntryResult, error) {
+	o := newBulkOptions(opts)
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].ID() < instances[j].ID()
+	})
+
+	results := make([]plugGo.EntryResult, len(instances))
+	sem := make(chan struct{}, o.concurrency)
+	var wg sync.WaitGroup
+	for i, instance := range instances {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				results[i] = plugGo.EntryResult{
+					Type: instance.PluginType(), Name: instance.ID(),
+					Outcome: plugGo.OutcomeCancelled, Error: ctx.Err().Error(),
+				}
+				return
+			}
+			results[i] = runWithTimeout(ctx, instance, op, o.timeout)
+		}()
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, r := range results {
+		if r.Outcome != plugGo.OutcomeOK {
+			errs = append(errs, errors.New(r.String()))
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// runWithTimeout runs an operation on one instance with a timeout.
+func runWithTimeout(ctx context.Context, instance *plugGo.PluginInstance, op func(context.Context, *plugGo.PluginInstance) error, timeout time.Duration) plugGo.EntryResult {
+	result := plugGo.EntryResult{Type: instance.PluginType(), Name: instance.ID()}
+	opCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	begin := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- op(opCtx, instance)
+	}()
+
+	select {
+	case err := <-done:
+		result.Duration = time.Since(begin)
+		result.Outcome = plugGo.OutcomeOK
+		if err != nil {
+			result.Outcome, result.Error = plugGo.OutcomeError, err.Error()
+		}
+	case <-opCtx.Done():
+		result.Duration = time.Since(begin)
+		result.Outcome, result.Error = plugGo.OutcomeTimeout, fmt.Sprintf("still running after %v, abandoned", timeout)
+	}
+	return result
+}
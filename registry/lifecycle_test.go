@@ -0,0 +1,73 @@
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/seencxy/plugGo"
+)
+
+func TestShutdownHookIsOptIn(t *testing.T) {
+	if contains(plugGo.GlobalAppCtx.ShutdownHookNames(), shutdownHookName) {
+		t.Fatal("importing the registry installed its shutdown hook")
+	}
+
+	InstallShutdownHook()
+	InstallShutdownHook()
+	defer UninstallShutdownHook()
+	count := 0
+	for _, name := range plugGo.GlobalAppCtx.ShutdownHookNames() {
+		if name == shutdownHookName {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("shutdown hook installed %d times, want once", count)
+	}
+
+	UninstallShutdownHook()
+	if contains(plugGo.GlobalAppCtx.ShutdownHookNames(), shutdownHookName) {
+		t.Error("shutdown hook still installed")
+	}
+}
+
+func TestStartAllSkipsDisabledInstances(t *testing.T) {
+	entries, err := BuildEntries([]byte(`
+registrytest:
+  - name: on
+  - name: off
+    enabled: false
+`), testPluginType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		t.Cleanup(func() { _ = entry.(*plugGo.PluginEntry).Remove() })
+	}
+	on, off := EntryInstanceID(testPluginType, "on"), EntryInstanceID(testPluginType, "off")
+
+	results, err := StartAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	started := resultNames(results)
+	if !contains(started, on) || contains(started, off) {
+		t.Fatalf("StartAll started %v, want %s but not %s", started, on, off)
+	}
+
+	results, err = StartAll(context.Background(), WithIncludeDisabled())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if started := resultNames(results); !contains(started, off) {
+		t.Errorf("StartAll(WithIncludeDisabled) started %v, want %s", started, off)
+	}
+}
+
+func resultNames(results []plugGo.EntryResult) []string {
+	names := make([]string, len(results))
+	for i, result := range results {
+		names[i] = result.Name
+	}
+	return names
+}
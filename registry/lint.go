@@ -0,0 +1,240 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/seencxy/plugGo"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigIssue is a problem found in a boot.yaml by LintConfig.
+type ConfigIssue struct {
+	Line     int    // 1-based line of the problem (0 if unknown)
+	Section  string // Top-level section ("" for file-level problems)
+	Instance string // Instance name ("" for section-level problems)
+	Message  string
+}
+
+// String formats the issue as "line: section/instance: message".
+func (i ConfigIssue) String() string {
+	where := i.Section
+	if i.Instance != "" {
+		where += "/" + i.Instance
+	}
+	if where != "" {
+		where += ": "
+	}
+	return fmt.Sprintf("%d: %s%s", i.Line, where, i.Message)
+}
+
+// InstanceConfig is the effective config of one boot.yaml instance:
+// the factory's DefaultConfig overlaid with the instance's keys.
+type InstanceConfig struct {
+	PluginType string
+	Name       string
+	Line       int
+	Enabled    bool
+	Labels     map[string]string
+	Config     interface{}
+}
+
+// ConfigReport is the result of LintConfig.
+type ConfigReport struct {
+	Issues    []ConfigIssue    // Sorted by line
+	Instances []InstanceConfig // Instances that parsed and validated, in file order
+}
+
+// OK reports whether no problems were found.
+func (r ConfigReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// LintConfig checks a boot.yaml against the registered factories without creating instances.
+// It reports unknown top-level sections, plugin sections that are not lists, duplicate
+// instance names (instance IDs are global, so across sections too), fields that don't
+// parse into the factory config, and ValidateConfig failures, each with its line.
+//
+// Parameters:
+//   - raw: raw boot.yaml content
+//   - knownSections: sections consumed by user Entries rather than plugin factories
+//
+// Returns:
+//   - ConfigReport: problems found and the effective config of each valid instance
+func LintConfig(raw []byte, knownSections ...string) ConfigReport {
+	var report ConfigReport
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		report.Issues = yamlErrorIssues(err, 0, "", "")
+		return report
+	}
+	if len(doc.Content) == 0 {
+		return report
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		report.Issues = append(report.Issues, ConfigIssue{Line: root.Line, Message: "config must be a mapping of sections"})
+		return report
+	}
+
+	known := make(map[string]bool, len(knownSections))
+	for _, section := range knownSections {
+		known[section] = true
+	}
+	factories := GetAllFactories()
+
+	sections := make(map[string]int)  // section -> line
+	instances := make(map[string]int) // instance ID -> line
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		section := key.Value
+
+		if line, exists := sections[section]; exists {
+			report.Issues = append(report.Issues, ConfigIssue{
+				Line: key.Line, Section: section,
+				Message: fmt.Sprintf("duplicate section (first defined at line %d)", line),
+			})
+			continue
+		}
+		sections[section] = key.Line
+
+		factory, ok := factories[section]
+		if !ok {
+			if !known[section] {
+				message := fmt.Sprintf("unknown section %q", section)
+				if suggestion := closestName(section, factories); suggestion != "" {
+					message += fmt.Sprintf(" (did you mean %q?)", suggestion)
+				}
+				report.Issues = append(report.Issues, ConfigIssue{Line: key.Line, Section: section, Message: message})
+			}
+			continue
+		}
+
+		if value.Kind != yaml.SequenceNode {
+			report.Issues = append(report.Issues, ConfigIssue{
+				Line: value.Line, Section: section,
+				Message: "section must be a list of instances",
+			})
+			continue
+		}
+		for index, item := range value.Content {
+			lintInstance(&report, factory, section, index, item, instances)
+		}
+	}
+
+	sort.SliceStable(report.Issues, func(i, j int) bool { return report.Issues[i].Line < report.Issues[j].Line })
+	return report
+}
+
+// lintInstance checks one element of a plugin section and records its effective config.
+func lintInstance(report *ConfigReport, factory plugGo.PluginFactory, section string, index int, item *yaml.Node, instances map[string]int) {
+	var meta plugGo.InstanceMeta
+	if err := item.Decode(&meta); err != nil {
+		report.Issues = append(report.Issues, yamlErrorIssues(err, item.Line, section, fmt.Sprintf("[%d]", index))...)
+		return
+	}
+
+	name := meta.Name
+	if name == "" {
+		name = fmt.Sprintf("%s-%d", section, index)
+	}
+	id := EntryInstanceID(section, name)
+	if line, exists := instances[id]; exists {
+		report.Issues = append(report.Issues, ConfigIssue{
+			Line: item.Line, Section: section, Instance: name,
+			Message: fmt.Sprintf("duplicate instance name (first defined at line %d)", line),
+		})
+		return
+	}
+	instances[id] = item.Line
+
+	if err := validateLabels(meta.Labels); err != nil {
+		report.Issues = append(report.Issues, ConfigIssue{
+			Line: item.Line, Section: section, Instance: name,
+			Message: fmt.Sprintf("invalid labels: %v", err),
+		})
+		return
+	}
+
+	cfg := factory.DefaultConfig()
+	if err := item.Decode(cfg); err != nil {
+		report.Issues = append(report.Issues, yamlErrorIssues(err, item.Line, section, name)...)
+		return
+	}
+	if err := factory.ValidateConfig(cfg); err != nil {
+		report.Issues = append(report.Issues, ConfigIssue{
+			Line: item.Line, Section: section, Instance: name,
+			Message: fmt.Sprintf("invalid config: %v", err),
+		})
+		return
+	}
+
+	report.Instances = append(report.Instances, InstanceConfig{
+		PluginType: section,
+		Name:       name,
+		Line:       item.Line,
+		Enabled:    meta.IsEnabled(),
+		Labels:     meta.Labels,
+		Config:     cfg,
+	})
+}
+
+// yamlLinePattern extracts the line yaml.v3 reports in its error messages.
+var yamlLinePattern = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// yamlErrorIssues converts a YAML parse or decode error into issues,
+// one per field for type errors, using the line in the message when there is one.
+func yamlErrorIssues(err error, line int, section, instance string) []ConfigIssue {
+	messages := []string{err.Error()}
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	}
+
+	issues := make([]ConfigIssue, 0, len(messages))
+	for _, message := range messages {
+		issue := ConfigIssue{Line: line, Section: section, Instance: instance, Message: message}
+		if m := yamlLinePattern.FindStringSubmatch(message); m != nil {
+			issue.Line, _ = strconv.Atoi(m[1])
+			issue.Message = m[2]
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// closestName returns the factory name within two edits of name, if any.
+func closestName(name string, factories map[string]plugGo.PluginFactory) string {
+	best, bestDistance := "", 3
+	for candidate := range factories {
+		if d := editDistance(name, candidate); d < bestDistance || (d == bestDistance && candidate < best) {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -1,10 +1,14 @@
 package registry
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/seencxy/plugGo"
+	plugGoConfig "github.com/seencxy/plugGo/config"
 )
 
 // Registry is the plugin registry.
@@ -12,17 +16,36 @@ import (
 type Registry struct {
 	factories map[string]plugGo.PluginFactory   // key: plugin type name
 	instances map[string]*plugGo.PluginInstance // key: instance ID
+	auditSink plugGo.AuditSink                  // applied to every instance
+	bootTypes map[string]bool                   // plugin types with a Boot registration function
 	mu        sync.RWMutex
+
+	// Indexes of instances, maintained with instances
+	byType  map[string]map[string]*plugGo.PluginInstance            // plugin type -> instance ID
+	byLabel map[string]map[string]map[string]*plugGo.PluginInstance // label key -> value -> instance ID
+	labels  map[string]map[string]string                            // instance ID -> indexed labels
+
+	disabled map[string]bool // IDs of instances created disabled (see WithEnabled)
 }
 
 // defaultRegistry is the default global registry instance.
 var defaultRegistry = &Registry{
 	factories: make(map[string]plugGo.PluginFactory),
 	instances: make(map[string]*plugGo.PluginInstance),
+	bootTypes: make(map[string]bool),
+	byType:    make(map[string]map[string]*plugGo.PluginInstance),
+	byLabel:   make(map[string]map[string]map[string]*plugGo.PluginInstance),
+	labels:    make(map[string]map[string]string),
+	disabled:  make(map[string]bool),
 }
 
+// logger is the registry logger.
+var logger = plugGo.NewDefaultLogger("registry")
+
 // RegisterFactory registers a plugin factory.
 // Plugin factories typically call this method in their init() function for auto-registration.
+// Registering a factory also lets Boot build Entries from the factory's boot.yaml section
+// (see BuildEntries), so plugins don't need their own Entry implementation.
 //
 // Parameters:
 //   - factory: the plugin factory to register
@@ -31,9 +54,140 @@ var defaultRegistry = &Registry{
 //   - If plugin type name already exists, new factory will override old factory
 //   - This method is thread-safe
 func RegisterFactory(factory plugGo.PluginFactory) {
+	pluginType := factory.Name()
+
 	defaultRegistry.mu.Lock()
-	defer defaultRegistry.mu.Unlock()
-	defaultRegistry.factories[factory.Name()] = factory
+	defaultRegistry.factories[pluginType] = factory
+	first := !defaultRegistry.bootTypes[pluginType]
+	defaultRegistry.bootTypes[pluginType] = true
+	defaultRegistry.mu.Unlock()
+
+	if first {
+		// Elements that fail to decode or validate are reported by Boot.Bootstrap
+		plugGo.RegisterPluginEntryRegFuncWithError(func(raw []byte) (map[string]plugGo.Entry, error) {
+			return BuildEntries(raw, pluginType)
+		})
+	}
+}
+
+// BuildEntries creates one plugin instance per element of the factory's boot.yaml section
+// and wraps each as a plugGo.PluginEntry.
+// Each element is parsed into the factory's DefaultConfig (so defaults apply), validated
+// and created through CreateInstance; the instance ID is EntryInstanceID(pluginType, name),
+// so sections of different plugin types may use the same names.
+//
+// Parameters:
+//   - raw: raw boot.yaml content
+//   - pluginType: plugin type name, also the section key
+//
+// Returns:
+//   - map[string]plugGo.Entry: created Entries by instance name
+//   - error: joined errors of elements that could not be created, a
+//     *plugGo.EntryBuildError per element
+func BuildEntries(raw []byte, pluginType string) (map[string]plugGo.Entry, error) {
+	result := make(map[string]plugGo.Entry)
+
+	if !plugGoConfig.HasYAMLSection(raw, pluginType) {
+		return result, nil
+	}
+
+	factory, ok := GetFactory(pluginType)
+	if !ok {
+		return result, fmt.Errorf("plugin factory not found: %s", pluginType)
+	}
+
+	items, err := plugGoConfig.GetYAMLSectionItems(raw, pluginType)
+	if err != nil {
+		return result, err
+	}
+
+	var errs []error
+	for i, item := range items {
+		entry, err := buildEntry(factory, item, i, func(name string) bool {
+			_, exists := result[name]
+			return exists
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		result[entry.GetName()] = entry
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// BuildEntry creates one plugin instance from a single boot.yaml element and wraps it
+// as a plugGo.PluginEntry, e.g. to add an instance at runtime with Boot.AddEntry.
+// The element is parsed like in BuildEntries; it must have a name.
+//
+// Parameters:
+//   - pluginType: plugin type name
+//   - item: YAML of one section element, e.g. "name: extra\nenabled: true\n..."
+//
+// Returns:
+//   - *plugGo.PluginEntry: the created Entry, not started
+//   - error: returns error if the element is invalid or the instance can't be created
+func BuildEntry(pluginType string, item []byte) (*plugGo.PluginEntry, error) {
+	factory, ok := GetFactory(pluginType)
+	if !ok {
+		return nil, fmt.Errorf("plugin factory not found: %s", pluginType)
+	}
+	var meta plugGo.InstanceMeta
+	if err := plugGoConfig.UnmarshalYAML(item, &meta); err != nil {
+		return nil, fmt.Errorf("%s: %w", pluginType, err)
+	}
+	if meta.Name == "" {
+		return nil, fmt.Errorf("%s: instance name is required", pluginType)
+	}
+	return buildEntry(factory, item, 0, nil)
+}
+
+// buildEntry creates the Entry of the i-th element of a factory's section.
+// taken reports names already used in the section (nil to skip the check).
+func buildEntry(factory plugGo.PluginFactory, item []byte, i int, taken func(name string) bool) (*plugGo.PluginEntry, error) {
+	pluginType := factory.Name()
+
+	var meta plugGo.InstanceMeta
+	if err := plugGoConfig.UnmarshalYAML(item, &meta); err != nil {
+		return nil, &plugGo.EntryBuildError{Type: pluginType, Name: fmt.Sprintf("%s[%d]", pluginType, i), Err: err}
+	}
+
+	// Instance name: prefer name from config, otherwise auto-generate
+	name := meta.Name
+	if name == "" {
+		name = fmt.Sprintf("%s-%d", pluginType, i)
+	}
+	if taken != nil && taken(name) {
+		return nil, &plugGo.EntryBuildError{Type: pluginType, Name: name, Err: fmt.Errorf("duplicate instance name in %s[%d]", pluginType, i)}
+	}
+
+	// Parse into the factory's default config so unset fields keep their defaults
+	cfg := factory.DefaultConfig()
+	if err := plugGoConfig.UnmarshalYAML(item, cfg); err != nil {
+		return nil, &plugGo.EntryBuildError{Type: pluginType, Name: name, Err: err}
+	}
+
+	instanceLogger := plugGo.NewStandardLogger(fmt.Sprintf("%s-%s", pluginType, name), plugGo.ParseLogLevel(meta.LogLevel))
+	instanceID := EntryInstanceID(pluginType, name)
+	instance, err := CreateInstance(pluginType, instanceID, cfg, instanceLogger, WithLabels(meta.Labels), WithEnabled(meta.IsEnabled()))
+	if err != nil {
+		return nil, &plugGo.EntryBuildError{Type: pluginType, Name: name, Err: err}
+	}
+
+	entry := plugGo.NewPluginEntry(name, instance, meta.IsEnabled())
+	entry.SetBootstrapTimeout(meta.BootstrapTimeout)
+	entry.SetBootstrapOrder(meta.BootstrapOrder)
+	entry.SetOnRemove(func() error {
+		return RemoveInstance(instanceID)
+	})
+	return entry, nil
+}
+
+// EntryInstanceID returns the registry instance ID of the Entry built from the
+// boot.yaml element named name in the pluginType section, e.g. "announcement/official".
+func EntryInstanceID(pluginType, name string) string {
+	return pluginType + "/" + name
 }
 
 // GetFactory returns the factory by plugin type name.
@@ -66,6 +220,32 @@ func GetAllFactories() map[string]plugGo.PluginFactory {
 	return result
 }
 
+// createOptions holds CreateInstance options.
+type createOptions struct {
+	labels   map[string]string
+	disabled bool
+}
+
+// CreateOption is a CreateInstance option function.
+type CreateOption func(*createOptions)
+
+// WithLabels sets the labels of the created instance, used by selectors
+// such as "team=payments". Keys and values use letters, digits and -_./.
+func WithLabels(labels map[string]string) CreateOption {
+	return func(o *createOptions) {
+		o.labels = labels
+	}
+}
+
+// WithEnabled marks the created instance as enabled or not, like "enabled" in
+// boot.yaml. StartAll and StartSelected skip disabled instances unless called
+// with WithIncludeDisabled. Instances are enabled by default.
+func WithEnabled(enabled bool) CreateOption {
+	return func(o *createOptions) {
+		o.disabled = !enabled
+	}
+}
+
 // CreateInstance creates a plugin instance.
 //
 // Parameters:
@@ -73,11 +253,20 @@ func GetAllFactories() map[string]plugGo.PluginFactory {
 //   - instanceID: unique identifier for the instance
 //   - config: plugin config (if nil, uses default config)
 //   - logger: logger (if nil, uses default logger)
+//   - opts: WithLabels, WithEnabled
 //
 // Returns:
 //   - *plugGo.PluginInstance: created plugin instance
 //   - error: returns error if creation fails
-func CreateInstance(pluginType, instanceID string, config interface{}, logger plugGo.Logger) (*plugGo.PluginInstance, error) {
+func CreateInstance(pluginType, instanceID string, config interface{}, logger plugGo.Logger, opts ...CreateOption) (*plugGo.PluginInstance, error) {
+	var o createOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if err := validateLabels(o.labels); err != nil {
+		return nil, fmt.Errorf("invalid labels: %w", err)
+	}
+
 	defaultRegistry.mu.Lock()
 	defer defaultRegistry.mu.Unlock()
 
@@ -115,9 +304,17 @@ func CreateInstance(pluginType, instanceID string, config interface{}, logger pl
 
 	// Wrap as PluginInstance
 	instance := plugGo.NewPluginInstance(instanceID, pluginType, plugin, config, factory)
+	if defaultRegistry.auditSink != nil {
+		instance.SetAuditSink(defaultRegistry.auditSink)
+	}
+	instance.SetLabels(o.labels)
 
 	// Register instance
 	defaultRegistry.instances[instanceID] = instance
+	defaultRegistry.index(instance, instance.Labels())
+	if o.disabled {
+		defaultRegistry.disabled[instanceID] = true
+	}
 
 	return instance, nil
 }
@@ -143,18 +340,69 @@ func GetInstance(instanceID string) (*plugGo.PluginInstance, bool) {
 //   - pluginType: plugin type name
 //
 // Returns:
-//   - []*plugGo.PluginInstance: all instances of this type
+//   - []*plugGo.PluginInstance: all instances of this type, sorted by ID
 func GetInstancesByType(pluginType string) []*plugGo.PluginInstance {
 	defaultRegistry.mu.RLock()
 	defer defaultRegistry.mu.RUnlock()
+	return sortedInstances(defaultRegistry.byType[pluginType])
+}
 
-	var result []*plugGo.PluginInstance
-	for _, instance := range defaultRegistry.instances {
-		if instance.PluginType() == pluginType {
-			result = append(result, instance)
+// SelectInstances returns the instances whose labels match a selector
+// (see ParseSelector), e.g. "team=payments,env!=dev".
+//
+// Parameters:
+//   - selector: label selector; empty selects every instance
+//
+// Returns:
+//   - []*plugGo.PluginInstance: matching instances, sorted by ID
+//   - error: returns error if the selector is malformed
+func SelectInstances(selector string) ([]*plugGo.PluginInstance, error) {
+	sel, err := ParseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return Select(sel), nil
+}
+
+// Select returns the instances whose labels match a parsed selector, sorted by ID.
+// Equality and set requirements are answered from the label index.
+func Select(sel Selector) []*plugGo.PluginInstance {
+	defaultRegistry.mu.RLock()
+	defer defaultRegistry.mu.RUnlock()
+
+	result := make(map[string]*plugGo.PluginInstance)
+	for id, instance := range defaultRegistry.candidates(sel) {
+		if sel.Matches(defaultRegistry.labels[id]) {
+			result[id] = instance
 		}
 	}
-	return result
+	return sortedInstances(result)
+}
+
+// SetLabels replaces the labels of an instance and updates the label index.
+//
+// Parameters:
+//   - instanceID: unique identifier of the instance
+//   - labels: the new labels; nil removes every label
+//
+// Returns:
+//   - error: returns error if instance does not exist or a label is invalid
+func SetLabels(instanceID string, labels map[string]string) error {
+	if err := validateLabels(labels); err != nil {
+		return fmt.Errorf("invalid labels: %w", err)
+	}
+
+	defaultRegistry.mu.Lock()
+	defer defaultRegistry.mu.Unlock()
+
+	instance, exists := defaultRegistry.instances[instanceID]
+	if !exists {
+		return fmt.Errorf("instance not found: %s", instanceID)
+	}
+	defaultRegistry.unindex(instance)
+	instance.SetLabels(labels)
+	defaultRegistry.index(instance, instance.Labels())
+	return nil
 }
 
 // GetAllInstances returns all plugin instances.
@@ -173,25 +421,177 @@ func GetAllInstances() []*plugGo.PluginInstance {
 }
 
 // RemoveInstance removes a plugin instance.
-// Note: caller should stop the plugin before removing.
+// All status subscriptions of the instance are cancelled.
+// An active instance (starting, running, reloading or stopping) is refused with
+// ErrInstanceActive unless WithForce is given; use StopAndRemove to stop it first.
 //
 // Parameters:
 //   - instanceID: unique identifier of the instance
+//   - opts: WithForce
 //
 // Returns:
-//   - error: returns error if instance does not exist
-func RemoveInstance(instanceID string) error {
+//   - error: returns error if instance does not exist or is active
+func RemoveInstance(instanceID string, opts ...RemoveOption) error {
+	var o removeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	defaultRegistry.mu.Lock()
 	defer defaultRegistry.mu.Unlock()
 
-	if _, exists := defaultRegistry.instances[instanceID]; !exists {
+	instance, exists := defaultRegistry.instances[instanceID]
+	if !exists {
 		return fmt.Errorf("instance not found: %s", instanceID)
 	}
+	if status := instance.Status(); isActive(status) {
+		if !o.force {
+			return fmt.Errorf("cannot remove instance %s (%s): %w", instanceID, status, ErrInstanceActive)
+		}
+		logger.Warn(fmt.Sprintf("Force removing instance %s while %s", instanceID, status))
+	}
+
+	defaultRegistry.remove(instance)
+	return nil
+}
 
-	delete(defaultRegistry.instances, instanceID)
+// remove unregisters an instance and cancels its status subscriptions.
+// Note: caller must hold r.mu.
+func (r *Registry) remove(instance *plugGo.PluginInstance) {
+	delete(r.instances, instance.ID())
+	delete(r.disabled, instance.ID())
+	r.unindex(instance)
+	instance.UnsubscribeAll()
+}
+
+// Subscribe subscribes to status events of a plugin instance.
+// The instance's current status is replayed as the first event.
+//
+// Parameters:
+//   - instanceID: unique identifier of the instance
+//   - bufferSize: per-subscriber buffer size (default used if not positive)
+//
+// Returns:
+//   - *plugGo.StatusSubscription: the new subscription
+//   - error: returns error if instance does not exist
+func Subscribe(instanceID string, bufferSize int) (*plugGo.StatusSubscription, error) {
+	instance, ok := GetInstance(instanceID)
+	if !ok {
+		return nil, fmt.Errorf("instance not found: %s", instanceID)
+	}
+	return instance.Subscribe(bufferSize), nil
+}
+
+// Unsubscribe cancels a status subscription of a plugin instance.
+//
+// Parameters:
+//   - instanceID: unique identifier of the instance
+//   - sub: subscription returned by Subscribe
+//
+// Returns:
+//   - error: returns error if instance or subscription does not exist
+func Unsubscribe(instanceID string, sub *plugGo.StatusSubscription) error {
+	instance, ok := GetInstance(instanceID)
+	if !ok {
+		return fmt.Errorf("instance not found: %s", instanceID)
+	}
+	if !instance.Unsubscribe(sub) {
+		return fmt.Errorf("subscription not found for instance: %s", instanceID)
+	}
 	return nil
 }
 
+// ConfigUpdate is a new config for one instance, identified by ID.
+type ConfigUpdate struct {
+	InstanceID string
+	Config     interface{}
+}
+
+// ApplyConfigs applies new configs to several instances as one transaction.
+// Configs are validated first and instances reloaded in the given order; if any
+// reload fails, every instance already changed is rolled back to its previous config.
+//
+// Parameters:
+//   - ctx: context for restarts during rollback, may carry the actor (plugGo.WithActor)
+//   - updates: new configs in reload order
+//
+// Returns:
+//   - *plugGo.ConfigTxResult: per-instance outcomes
+//   - error: returns error if an instance does not exist or is listed twice
+func ApplyConfigs(ctx context.Context, updates []ConfigUpdate) (*plugGo.ConfigTxResult, error) {
+	changes := make([]plugGo.ConfigChange, 0, len(updates))
+	for _, update := range updates {
+		instance, ok := GetInstance(update.InstanceID)
+		if !ok {
+			return nil, fmt.Errorf("instance not found: %s", update.InstanceID)
+		}
+		changes = append(changes, plugGo.ConfigChange{Instance: instance, Config: update.Config})
+	}
+	return plugGo.ApplyConfigs(ctx, changes)
+}
+
+// GetHistory returns the lifecycle history of a plugin instance.
+//
+// Parameters:
+//   - instanceID: unique identifier of the instance
+//
+// Returns:
+//   - []plugGo.HistoryEvent: events from oldest to newest
+//   - error: returns error if instance does not exist
+func GetHistory(instanceID string) ([]plugGo.HistoryEvent, error) {
+	instance, ok := GetInstance(instanceID)
+	if !ok {
+		return nil, fmt.Errorf("instance not found: %s", instanceID)
+	}
+	return instance.History(), nil
+}
+
+// GetAllHistory returns the lifecycle history of all instances merged by time.
+//
+// Returns:
+//   - []plugGo.HistoryEvent: events from oldest to newest
+func GetAllHistory() []plugGo.HistoryEvent {
+	var result []plugGo.HistoryEvent
+	for _, instance := range GetAllInstances() {
+		result = append(result, instance.History()...)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+	return result
+}
+
+// GetHealth returns the component health reported by a plugin instance.
+//
+// Parameters:
+//   - instanceID: unique identifier of the instance
+//
+// Returns:
+//   - []plugGo.ComponentHealth: component health (nil if the plugin doesn't report health)
+//   - error: returns error if instance does not exist
+func GetHealth(instanceID string) ([]plugGo.ComponentHealth, error) {
+	instance, ok := GetInstance(instanceID)
+	if !ok {
+		return nil, fmt.Errorf("instance not found: %s", instanceID)
+	}
+	return instance.Health(), nil
+}
+
+// SetAuditSink sets the audit sink for all existing and future instances.
+// Pass nil to disable auditing.
+//
+// Parameters:
+//   - sink: audit sink, e.g. plugGo.NewJSONLinesAuditSink("audit.jsonl")
+func SetAuditSink(sink plugGo.AuditSink) {
+	defaultRegistry.mu.Lock()
+	defer defaultRegistry.mu.Unlock()
+
+	defaultRegistry.auditSink = sink
+	for _, instance := range defaultRegistry.instances {
+		instance.SetAuditSink(sink)
+	}
+}
+
 // CountFactories returns the number of registered plugin factories.
 func CountFactories() int {
 	defaultRegistry.mu.RLock()
@@ -244,3 +644,89 @@ func GetAll() []plugGo.Plugin {
 func Count() int {
 	return CountInstances()
 }
+
+// index adds an instance to the type and label indexes.
+// Note: caller must hold r.mu.
+func (r *Registry) index(instance *plugGo.PluginInstance, labels map[string]string) {
+	id := instance.ID()
+	if r.byType[instance.PluginType()] == nil {
+		r.byType[instance.PluginType()] = make(map[string]*plugGo.PluginInstance)
+	}
+	r.byType[instance.PluginType()][id] = instance
+
+	r.labels[id] = labels
+	for key, value := range labels {
+		if r.byLabel[key] == nil {
+			r.byLabel[key] = make(map[string]map[string]*plugGo.PluginInstance)
+		}
+		if r.byLabel[key][value] == nil {
+			r.byLabel[key][value] = make(map[string]*plugGo.PluginInstance)
+		}
+		r.byLabel[key][value][id] = instance
+	}
+}
+
+// unindex removes an instance from the type and label indexes.
+// Note: caller must hold r.mu.
+func (r *Registry) unindex(instance *plugGo.PluginInstance) {
+	id := instance.ID()
+	if byID := r.byType[instance.PluginType()]; byID != nil {
+		delete(byID, id)
+		if len(byID) == 0 {
+			delete(r.byType, instance.PluginType())
+		}
+	}
+
+	for key, value := range r.labels[id] {
+		byValue := r.byLabel[key]
+		delete(byValue[value], id)
+		if len(byValue[value]) == 0 {
+			delete(byValue, value)
+		}
+		if len(byValue) == 0 {
+			delete(r.byLabel, key)
+		}
+	}
+	delete(r.labels, id)
+}
+
+// candidates returns the instances a selector can match: the smallest index set of
+// its equality and set requirements, or every instance if it has none.
+// Note: caller must hold r.mu.
+func (r *Registry) candidates(sel Selector) map[string]*plugGo.PluginInstance {
+	var best map[string]*plugGo.PluginInstance
+	found := false
+	for _, req := range sel.requirements {
+		if req.op != opEquals && req.op != opIn {
+			continue
+		}
+		set := r.byLabel[req.key][req.values[0]]
+		if len(req.values) > 1 {
+			set = make(map[string]*plugGo.PluginInstance)
+			for _, value := range req.values {
+				for id, instance := range r.byLabel[req.key][value] {
+					set[id] = instance
+				}
+			}
+		}
+		if !found || len(set) < len(best) {
+			best, found = set, true
+		}
+	}
+	if !found {
+		return r.instances
+	}
+	return best
+}
+
+// sortedInstances returns the instances of a set sorted by ID.
+func sortedInstances(set map[string]*plugGo.PluginInstance) []*plugGo.PluginInstance {
+	result := make([]*plugGo.PluginInstance, 0, len(set))
+	for _, instance := range set {
+		result = append(result, instance)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID() < result[j].ID()
+	})
+	return result
+}
@@ -0,0 +1,278 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/seencxy/plugGo"
+)
+
+// testPluginType is the plugin type of the test factory.
+const testPluginType = "registrytest"
+
+type testPlugin struct {
+	id         string
+	pluginType string
+	logger     plugGo.Logger
+	statusCh   chan plugGo.StatusEvent
+}
+
+func (p *testPlugin) Start(ctx context.Context) error         { return nil }
+func (p *testPlugin) Stop(ctx context.Context) error          { return nil }
+func (p *testPlugin) GetLogger() plugGo.Logger                { return p.logger }
+func (p *testPlugin) SetLogger(logger plugGo.Logger)          { p.logger = logger }
+func (p *testPlugin) Status() plugGo.PluginStatus             { return plugGo.StatusIdle }
+func (p *testPlugin) StatusNotify() <-chan plugGo.StatusEvent { return p.statusCh }
+func (p *testPlugin) GetNotifyChannel() chan any              { return nil }
+func (p *testPlugin) ID() string                              { return p.id }
+func (p *testPlugin) PluginType() string                      { return p.pluginType }
+func (p *testPlugin) Version() string                         { return "1.0.0" }
+func (p *testPlugin) Reload(config interface{}) error         { return nil }
+
+type testFactory struct{ name string }
+
+func (f testFactory) Name() string                          { return f.name }
+func (testFactory) Version() string                         { return "1.0.0" }
+func (testFactory) DefaultConfig() interface{}              { return &struct{}{} }
+func (testFactory) ValidateConfig(config interface{}) error { return nil }
+func (f testFactory) Create(instanceID string, config interface{}, logger plugGo.Logger) (plugGo.Plugin, error) {
+	return &testPlugin{id: instanceID, pluginType: f.name, logger: logger, statusCh: make(chan plugGo.StatusEvent, 1)}, nil
+}
+
+func init() {
+	RegisterFactory(testFactory{name: testPluginType})
+	RegisterFactory(testFactory{name: testPluginType + "-other"})
+}
+
+// createTestInstances creates instances of the test factory, removed when the test ends.
+func createTestInstances(t *testing.T, labels map[string]map[string]string) {
+	t.Helper()
+	for id, l := range labels {
+		if _, err := CreateInstance(testPluginType, id, nil, nil, WithLabels(l)); err != nil {
+			t.Fatalf("CreateInstance(%s) = %v", id, err)
+		}
+		t.Cleanup(func() { _ = RemoveInstance(id, WithForce()) })
+	}
+}
+
+// selectIDs returns the IDs of the instances matching a selector.
+func selectIDs(t *testing.T, selector string) []string {
+	t.Helper()
+	instances, err := SelectInstances(selector)
+	if err != nil {
+		t.Fatalf("SelectInstances(%q) = %v", selector, err)
+	}
+	var ids []string
+	for _, instance := range instances {
+		ids = append(ids, instance.ID())
+	}
+	return ids
+}
+
+// indexedIDs returns the IDs indexed under a label, nil if the label value isn't indexed.
+func indexedIDs(key, value string) []string {
+	defaultRegistry.mu.RLock()
+	defer defaultRegistry.mu.RUnlock()
+	set, ok := defaultRegistry.byLabel[key][value]
+	if !ok {
+		return nil
+	}
+	var ids []string
+	for _, instance := range sortedInstances(set) {
+		ids = append(ids, instance.ID())
+	}
+	return ids
+}
+
+func TestSelectInstances(t *testing.T) {
+	createTestInstances(t, map[string]map[string]string{
+		"sel-a1": {"team": "payments", "env": "prod"},
+		"sel-a2": {"team": "payments", "env": "dev"},
+		"sel-b1": {"team": "search", "env": "prod", "tier": ""},
+		"sel-c1": nil,
+	})
+
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{"team=payments", []string{"sel-a1", "sel-a2"}},
+		{"team=payments,env=prod", []string{"sel-a1"}},
+		{"team in (payments,search),env!=dev", []string{"sel-a1", "sel-b1"}},
+		{"env notin (prod),team", []string{"sel-a2"}},
+		{"tier", []string{"sel-b1"}},
+		{"tier=", []string{"sel-b1"}},
+		{"team=nobody", nil},
+	}
+	for _, tt := range tests {
+		if got := selectIDs(t, tt.selector); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SelectInstances(%q) = %v, want %v", tt.selector, got, tt.want)
+		}
+	}
+
+	// Selectors without equality requirements scan every instance, including other tests'
+	got := selectIDs(t, "!team")
+	if !contains(got, "sel-c1") || contains(got, "sel-a1") {
+		t.Errorf("SelectInstances(!team) = %v", got)
+	}
+
+	if _, err := SelectInstances("env in ()"); err == nil {
+		t.Error("SelectInstances accepted a malformed selector")
+	}
+}
+
+func TestSetLabelsUpdatesIndex(t *testing.T) {
+	createTestInstances(t, map[string]map[string]string{
+		"idx-a": {"team": "idx-payments", "env": "idx-dev"},
+		"idx-b": {"team": "idx-search"},
+	})
+
+	if err := SetLabels("idx-a", map[string]string{"team": "idx-search"}); err != nil {
+		t.Fatalf("SetLabels = %v", err)
+	}
+	if got := indexedIDs("team", "idx-search"); !reflect.DeepEqual(got, []string{"idx-a", "idx-b"}) {
+		t.Errorf("team=idx-search indexed %v", got)
+	}
+	if got := indexedIDs("team", "idx-payments"); got != nil {
+		t.Errorf("team=idx-payments still indexed %v", got)
+	}
+	if got := indexedIDs("env", "idx-dev"); got != nil {
+		t.Errorf("env=idx-dev still indexed %v", got)
+	}
+	if got := selectIDs(t, "team=idx-payments"); got != nil {
+		t.Errorf("selected stale labels %v", got)
+	}
+	instance, _ := GetInstance("idx-a")
+	if got := instance.Labels(); !reflect.DeepEqual(got, map[string]string{"team": "idx-search"}) {
+		t.Errorf("Labels = %v", got)
+	}
+
+	// Invalid labels are rejected and leave the labels and index unchanged
+	for _, labels := range []map[string]string{
+		{"team": "a,b"},
+		{"team": "a)"},
+		{"": "a"},
+	} {
+		if err := SetLabels("idx-a", labels); err == nil {
+			t.Errorf("SetLabels(%v) succeeded", labels)
+		}
+	}
+	if got := instance.Labels(); !reflect.DeepEqual(got, map[string]string{"team": "idx-search"}) {
+		t.Errorf("Labels after invalid SetLabels = %v", got)
+	}
+
+	if err := SetLabels("idx-a", nil); err != nil {
+		t.Fatalf("SetLabels(nil) = %v", err)
+	}
+	if got := indexedIDs("team", "idx-search"); !reflect.DeepEqual(got, []string{"idx-b"}) {
+		t.Errorf("team=idx-search indexed %v after clearing labels", got)
+	}
+
+	if err := SetLabels("idx-missing", map[string]string{"team": "a"}); err == nil {
+		t.Error("SetLabels of a missing instance succeeded")
+	}
+}
+
+func TestRemoveInstanceUpdatesIndex(t *testing.T) {
+	createTestInstances(t, map[string]map[string]string{
+		"rm-a": {"team": "rm-payments"},
+		"rm-b": {"team": "rm-payments"},
+	})
+
+	if err := RemoveInstance("rm-a"); err != nil {
+		t.Fatalf("RemoveInstance = %v", err)
+	}
+	if got := indexedIDs("team", "rm-payments"); !reflect.DeepEqual(got, []string{"rm-b"}) {
+		t.Errorf("team=rm-payments indexed %v", got)
+	}
+	if contains(instanceIDs(GetInstancesByType(testPluginType)), "rm-a") {
+		t.Error("removed instance still indexed by type")
+	}
+
+	if err := StopAndRemove(context.Background(), "rm-b"); err != nil {
+		t.Fatalf("StopAndRemove = %v", err)
+	}
+	defaultRegistry.mu.RLock()
+	_, indexed := defaultRegistry.byLabel["team"]["rm-payments"]
+	_, labeled := defaultRegistry.labels["rm-b"]
+	defaultRegistry.mu.RUnlock()
+	if indexed || labeled {
+		t.Errorf("empty label index entries left: value %v, labels %v", indexed, labeled)
+	}
+}
+
+func TestCreateInstanceRejectsInvalidLabels(t *testing.T) {
+	_, err := CreateInstance(testPluginType, "bad-labels", nil, nil, WithLabels(map[string]string{"team": "a,b"}))
+	if err == nil {
+		_ = RemoveInstance("bad-labels", WithForce())
+		t.Fatal("CreateInstance accepted an invalid label")
+	}
+	if _, ok := GetInstance("bad-labels"); ok {
+		t.Error("instance with invalid labels was registered")
+	}
+}
+
+func TestStopAndRemoveRetires(t *testing.T) {
+	createTestInstances(t, map[string]map[string]string{"retire-a": nil})
+	instance, _ := GetInstance("retire-a")
+	if err := instance.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := StopAndRemove(context.Background(), "retire-a"); err != nil {
+		t.Fatalf("StopAndRemove = %v", err)
+	}
+	if err := instance.Start(context.Background()); !errors.Is(err, plugGo.ErrInstanceRetired) {
+		t.Errorf("Start after StopAndRemove = %v, want ErrInstanceRetired", err)
+	}
+	if _, ok := GetInstance("retire-a"); ok {
+		t.Error("instance still registered")
+	}
+}
+
+func TestBuildEntriesSameNameAcrossTypes(t *testing.T) {
+	raw := []byte(`
+registrytest:
+  - name: main
+registrytest-other:
+  - name: main
+`)
+	for _, pluginType := range []string{testPluginType, testPluginType + "-other"} {
+		entries, err := BuildEntries(raw, pluginType)
+		if err != nil {
+			t.Fatalf("BuildEntries(%s) = %v", pluginType, err)
+		}
+		entry, ok := entries["main"].(*plugGo.PluginEntry)
+		if !ok {
+			t.Fatalf("BuildEntries(%s) = %v, want a main Entry", pluginType, entries)
+		}
+		t.Cleanup(func() { _ = entry.Remove() })
+		if got, want := entry.Instance().ID(), EntryInstanceID(pluginType, "main"); got != want {
+			t.Errorf("instance ID = %q, want %q", got, want)
+		}
+	}
+
+	entry, err := BuildEntry(testPluginType, []byte("name: main\n"))
+	if err == nil {
+		_ = entry.Remove()
+		t.Fatal("BuildEntry reused an existing instance ID")
+	}
+}
+
+func instanceIDs(instances []*plugGo.PluginInstance) []string {
+	ids := make([]string, len(instances))
+	for i, instance := range instances {
+		ids[i] = instance.ID()
+	}
+	return ids
+}
+
+func contains(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
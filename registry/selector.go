@@ -0,0 +1,301 @@
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// selectorOp is the operator of one selector requirement.
+type selectorOp int
+
+const (
+	opEquals    selectorOp = iota // key=value, key==value
+	opNotEquals                   // key!=value (also matches instances without key)
+	opIn                          // key in (a,b)
+	opNotIn                       // key notin (a,b) (also matches instances without key)
+	opExists                      // key
+	opNotExists                   // !key
+)
+
+// requirement is one comma-separated term of a selector.
+type requirement struct {
+	key    string
+	op     selectorOp
+	values []string // sorted; one value for = and !=, none for existence checks
+}
+
+// matches reports whether labels satisfy the requirement.
+func (r requirement) matches(labels map[string]string) bool {
+	value, ok := labels[r.key]
+	switch r.op {
+	case opEquals, opIn:
+		return ok && r.has(value)
+	case opNotEquals, opNotIn:
+		return !ok || !r.has(value)
+	case opExists:
+		return ok
+	case opNotExists:
+		return !ok
+	default:
+		return false
+	}
+}
+
+// has reports whether value is one of the requirement values.
+func (r requirement) has(value string) bool {
+	i := sort.SearchStrings(r.values, value)
+	return i < len(r.values) && r.values[i] == value
+}
+
+// String returns the requirement in selector syntax.
+func (r requirement) String() string {
+	switch r.op {
+	case opEquals:
+		return r.key + "=" + r.values[0]
+	case opNotEquals:
+		return r.key + "!=" + r.values[0]
+	case opIn:
+		return r.key + " in (" + strings.Join(r.values, ",") + ")"
+	case opNotIn:
+		return r.key + " notin (" + strings.Join(r.values, ",") + ")"
+	case opNotExists:
+		return "!" + r.key
+	default:
+		return r.key
+	}
+}
+
+// Selector selects instances by their labels. All requirements must match;
+// the zero Selector matches every instance.
+type Selector struct {
+	requirements []requirement
+}
+
+// ParseSelector parses a label selector: comma-separated requirements, all of which
+// must match. Supported requirements:
+//   - key=value, key==value: the label is set to value
+//   - key!=value: the label is not set to value, or not set at all
+//   - key in (a,b): the label is set to one of the values
+//   - key notin (a,b): the label is set to none of the values, or not set at all
+//   - key: the label is set
+//   - !key: the label is not set
+//
+// An empty selector matches every instance.
+//
+// Parameters:
+//   - selector: e.g. "team=payments,env!=dev"
+//
+// Returns:
+//   - Selector: the parsed selector
+//   - error: returns error if the selector is malformed
+func ParseSelector(selector string) (Selector, error) {
+	var s Selector
+	terms, err := splitSelector(selector)
+	if err != nil {
+		return Selector{}, err
+	}
+	for _, term := range terms {
+		r, err := parseRequirement(term)
+		if err != nil {
+			return Selector{}, fmt.Errorf("invalid selector %q: %w", selector, err)
+		}
+		s.requirements = append(s.requirements, r)
+	}
+	return s, nil
+}
+
+// SelectorFromLabels returns a selector matching instances carrying all the labels.
+func SelectorFromLabels(labels map[string]string) Selector {
+	var s Selector
+	for key, value := range labels {
+		s.requirements = append(s.requirements, requirement{key: key, op: opEquals, values: []string{value}})
+	}
+	sort.Slice(s.requirements, func(i, j int) bool {
+		return s.requirements[i].key < s.requirements[j].key
+	})
+	return s
+}
+
+// Matches reports whether labels satisfy every requirement of the selector.
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, r := range s.requirements {
+		if !r.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// Empty reports whether the selector matches every instance.
+func (s Selector) Empty() bool {
+	return len(s.requirements) == 0
+}
+
+// String returns the selector in the syntax ParseSelector accepts.
+func (s Selector) String() string {
+	terms := make([]string, len(s.requirements))
+	for i, r := range s.requirements {
+		terms[i] = r.String()
+	}
+	return strings.Join(terms, ",")
+}
+
+// splitSelector splits a selector at the commas outside parentheses.
+func splitSelector(selector string) ([]string, error) {
+	var terms []string
+	depth, start := 0, 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+			if depth > 1 {
+				return nil, fmt.Errorf("invalid selector %q: nested parentheses", selector)
+			}
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("invalid selector %q: unbalanced parentheses", selector)
+			}
+		case ',':
+			if depth == 0 {
+				terms = append(terms, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("invalid selector %q: unbalanced parentheses", selector)
+	}
+	terms = append(terms, selector[start:])
+
+	// An empty selector has no terms, but empty terms between commas are mistakes
+	if len(terms) == 1 && strings.TrimSpace(terms[0]) == "" {
+		return nil, nil
+	}
+	return terms, nil
+}
+
+// parseRequirement parses one selector term.
+func parseRequirement(term string) (requirement, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return requirement{}, fmt.Errorf("empty requirement")
+	}
+
+	var r requirement
+	switch {
+	case strings.HasPrefix(term, "!") && !strings.Contains(term, "="):
+		r = requirement{key: strings.TrimSpace(term[1:]), op: opNotExists}
+	case strings.Contains(term, "!="):
+		key, value, _ := strings.Cut(term, "!=")
+		r = requirement{key: strings.TrimSpace(key), op: opNotEquals, values: []string{strings.TrimSpace(value)}}
+	case strings.Contains(term, "=="):
+		key, value, _ := strings.Cut(term, "==")
+		r = requirement{key: strings.TrimSpace(key), op: opEquals, values: []string{strings.TrimSpace(value)}}
+	case strings.Contains(term, "="):
+		key, value, _ := strings.Cut(term, "=")
+		r = requirement{key: strings.TrimSpace(key), op: opEquals, values: []string{strings.TrimSpace(value)}}
+	case strings.Contains(term, "("):
+		var err error
+		if r, err = parseSetRequirement(term); err != nil {
+			return requirement{}, err
+		}
+	default:
+		r = requirement{key: term, op: opExists}
+	}
+
+	if err := validateLabelKey(r.key); err != nil {
+		return requirement{}, err
+	}
+	for _, value := range r.values {
+		if err := validateLabelValue(value); err != nil {
+			return requirement{}, err
+		}
+	}
+	sort.Strings(r.values)
+	return r, nil
+}
+
+// parseSetRequirement parses "key in (a,b)" and "key notin (a,b)".
+func parseSetRequirement(term string) (requirement, error) {
+	head, list, _ := strings.Cut(term, "(")
+	list, rest, ok := strings.Cut(list, ")")
+	if !ok || strings.TrimSpace(rest) != "" {
+		return requirement{}, fmt.Errorf("malformed set requirement %q", term)
+	}
+
+	fields := strings.Fields(head)
+	if len(fields) != 2 {
+		return requirement{}, fmt.Errorf("malformed set requirement %q", term)
+	}
+	r := requirement{key: fields[0]}
+	switch fields[1] {
+	case "in":
+		r.op = opIn
+	case "notin":
+		r.op = opNotIn
+	default:
+		return requirement{}, fmt.Errorf("unknown operator %q in %q", fields[1], term)
+	}
+
+	if strings.TrimSpace(list) == "" {
+		return requirement{}, fmt.Errorf("empty value set in %q", term)
+	}
+	for _, value := range strings.Split(list, ",") {
+		r.values = append(r.values, strings.TrimSpace(value))
+	}
+	return r, nil
+}
+
+// validateLabelKey checks that a label key is non-empty and made of letters,
+// digits and '-', '_', '.', '/'.
+func validateLabelKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("empty label key")
+	}
+	if !isLabelText(key) {
+		return fmt.Errorf("invalid label key %q: use letters, digits and -_./", key)
+	}
+	return nil
+}
+
+// validateLabelValue checks that a label value is made of letters, digits and
+// '-', '_', '.', '/'; empty values are allowed.
+func validateLabelValue(value string) error {
+	if !isLabelText(value) {
+		return fmt.Errorf("invalid label value %q: use letters, digits and -_./", value)
+	}
+	return nil
+}
+
+// isLabelText reports whether s only contains label characters.
+func isLabelText(s string) bool {
+	for _, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == '/':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// validateLabels checks the keys and values of a label set.
+func validateLabels(labels map[string]string) error {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := validateLabelKey(key); err != nil {
+			return err
+		}
+		if err := validateLabelValue(labels[key]); err != nil {
+			return fmt.Errorf("label %s: %w", key, err)
+		}
+	}
+	return nil
+}
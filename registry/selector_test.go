@@ -0,0 +1,145 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     string // String() of the parsed selector
+		wantErr  string // Substring of the error, "" if it parses
+	}{
+		{selector: "", want: ""},
+		{selector: "   ", want: ""},
+		{selector: "team=payments", want: "team=payments"},
+		{selector: "team==payments", want: "team=payments"},
+		{selector: " team = payments , env != dev ", want: "team=payments,env!=dev"},
+		{selector: "team=", want: "team="},
+		{selector: "team!=", want: "team!="},
+		{selector: "tier", want: "tier"},
+		{selector: "!tier", want: "!tier"},
+		{selector: "! tier", want: "!tier"},
+		{selector: "env in (prod, staging)", want: "env in (prod,staging)"},
+		{selector: "env in(staging,prod)", want: "env in (prod,staging)"},
+		{selector: "env notin (dev)", want: "env notin (dev)"},
+		{selector: "env in (prod,),team=a", want: "env in (,prod),team=a"},
+		{selector: "example.com/team=a_b-c.d", want: "example.com/team=a_b-c.d"},
+
+		{selector: "team=a,", wantErr: "empty requirement"},
+		{selector: ",team=a", wantErr: "empty requirement"},
+		{selector: "team=a,,env=b", wantErr: "empty requirement"},
+		{selector: "=payments", wantErr: "empty label key"},
+		{selector: "!", wantErr: "empty label key"},
+		{selector: "env in ()", wantErr: "empty value set"},
+		{selector: "env in ( )", wantErr: "empty value set"},
+		{selector: "env in (a", wantErr: "unbalanced parentheses"},
+		{selector: "env in a)", wantErr: "unbalanced parentheses"},
+		{selector: "env in ((a))", wantErr: "nested parentheses"},
+		{selector: "env in (a) b", wantErr: "malformed set requirement"},
+		{selector: "in (a)", wantErr: "malformed set requirement"},
+		{selector: "env has (a)", wantErr: "unknown operator"},
+		{selector: "env>=3", wantErr: "invalid label key"},
+		{selector: "env in a", wantErr: "invalid label key"},
+		{selector: "team=a=b", wantErr: "invalid label value"},
+		{selector: "team=pay ments", wantErr: "invalid label value"},
+		{selector: "env in (a b)", wantErr: "invalid label value"},
+		{selector: "!team=a", wantErr: "invalid label key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			sel, err := ParseSelector(tt.selector)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseSelector error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSelector = %v", err)
+			}
+			if got := sel.String(); got != tt.want {
+				t.Errorf("String = %q, want %q", got, tt.want)
+			}
+			if sel.Empty() != (tt.want == "") {
+				t.Errorf("Empty = %v", sel.Empty())
+			}
+			// String round-trips
+			again, err := ParseSelector(sel.String())
+			if err != nil || again.String() != sel.String() {
+				t.Errorf("ParseSelector(%q) = %q, %v", sel.String(), again.String(), err)
+			}
+		})
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	labels := map[string]string{"team": "payments", "env": "prod", "tier": ""}
+
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{"", true},
+		{"team=payments", true},
+		{"team=search", false},
+		{"team!=search", true},
+		{"owner!=bob", true},
+		{"owner=bob", false},
+		{"tier=", true},
+		{"tier", true},
+		{"!tier", false},
+		{"!owner", true},
+		{"env in (prod,staging)", true},
+		{"env in (dev)", false},
+		{"owner in (bob)", false},
+		{"env notin (dev)", true},
+		{"env notin (prod)", false},
+		{"owner notin (bob)", true},
+		{"team=payments,env=prod", true},
+		{"team=payments,env=dev", false},
+	}
+	for _, tt := range tests {
+		sel, err := ParseSelector(tt.selector)
+		if err != nil {
+			t.Fatalf("ParseSelector(%q) = %v", tt.selector, err)
+		}
+		if got := sel.Matches(labels); got != tt.want {
+			t.Errorf("%q matches = %v, want %v", tt.selector, got, tt.want)
+		}
+	}
+}
+
+func TestSelectorFromLabels(t *testing.T) {
+	sel := SelectorFromLabels(map[string]string{"team": "a", "env": "b"})
+	if got := sel.String(); got != "env=b,team=a" {
+		t.Errorf("String = %q", got)
+	}
+	if !sel.Matches(map[string]string{"team": "a", "env": "b", "x": "y"}) || sel.Matches(map[string]string{"team": "a"}) {
+		t.Error("unexpected Matches result")
+	}
+}
+
+func TestValidateLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  map[string]string
+		wantErr bool
+	}{
+		{name: "nil", labels: nil},
+		{name: "valid", labels: map[string]string{"team": "payments", "example.com/tier": "1", "empty": ""}},
+		{name: "empty key", labels: map[string]string{"": "a"}, wantErr: true},
+		{name: "comma in value", labels: map[string]string{"team": "a,b"}, wantErr: true},
+		{name: "parenthesis in value", labels: map[string]string{"team": "a)"}, wantErr: true},
+		{name: "space in value", labels: map[string]string{"team": "a b"}, wantErr: true},
+		{name: "equals in key", labels: map[string]string{"a=b": "c"}, wantErr: true},
+		{name: "bang in key", labels: map[string]string{"!a": "c"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := validateLabels(tt.labels); (err != nil) != tt.wantErr {
+			t.Errorf("%s: validateLabels = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package schedule
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock abstracts time so schedules can be tested deterministically.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer creates a timer firing after d.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is the subset of time.Timer used by the scheduler.
+type Timer interface {
+	// C returns the channel the time is delivered on.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing; returns false if it already fired or was stopped.
+	Stop() bool
+	// Reset changes the timer to fire after d; returns false if it had fired or been stopped.
+	Reset(d time.Duration) bool
+}
+
+// RealClock is the wall clock.
+var RealClock Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return &realTimer{timer: time.NewTimer(d)}
+}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t *realTimer) C() <-chan time.Time        { return t.timer.C }
+func (t *realTimer) Stop() bool                 { return t.timer.Stop() }
+func (t *realTimer) Reset(d time.Duration) bool { return t.timer.Reset(d) }
+
+// FakeClock is a manually advanced clock for tests.
+// Timers fire only when Advance or Set moves the time past their deadline.
+type FakeClock struct {
+	now    time.Time
+	timers []*fakeTimer // Pending timers
+	mu     sync.Mutex
+	cond   *sync.Cond // Signalled when timers are added
+}
+
+// NewFakeClock creates a fake clock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the fake time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer creates a timer firing when the clock reaches now+d.
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{clock: c, ch: make(chan time.Time, 1)}
+	c.schedule(t, d)
+	return t
+}
+
+// Advance moves the clock forward by d, firing due timers in deadline order.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	now := c.now.Add(d)
+	c.mu.Unlock()
+	c.Set(now)
+}
+
+// Set moves the clock to t, firing due timers in deadline order.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = t
+	sort.Slice(c.timers, func(i, j int) bool { return c.timers[i].deadline.Before(c.timers[j].deadline) })
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.deadline.After(t) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.active = false
+		select {
+		case timer.ch <- timer.deadline:
+		default:
+		}
+	}
+	c.timers = pending
+}
+
+// Timers returns the number of pending timers.
+func (c *FakeClock) Timers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// BlockUntil waits until at least n timers are pending, e.g. until every
+// scheduled job is waiting for its next run before advancing the clock.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+// schedule (re)arms a timer.
+// Note: caller must hold the lock (c.mu).
+func (c *FakeClock) schedule(t *fakeTimer, d time.Duration) {
+	t.deadline = c.now.Add(d)
+	if d <= 0 {
+		t.active = false
+		select {
+		case t.ch <- t.deadline:
+		default:
+		}
+		return
+	}
+	t.active = true
+	c.timers = append(c.timers, t)
+	c.cond.Broadcast()
+}
+
+// remove drops a pending timer.
+// Note: caller must hold the lock (c.mu).
+func (c *FakeClock) remove(t *fakeTimer) bool {
+	for i, timer := range c.timers {
+		if timer == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			t.active = false
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	ch       chan time.Time
+	deadline time.Time
+	active   bool // Pending in the clock (guarded by clock.mu)
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.ch }
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.remove(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	wasActive := t.clock.remove(t)
+	t.clock.schedule(t, d)
+	return wasActive
+}
@@ -0,0 +1,304 @@
+// Package schedule parses cron expressions and runs jobs on them.
+//
+// Supported expressions:
+//
+//	"*/5 * * * *"                 5 fields: minute hour day-of-month month day-of-week
+//	"30 */5 * * * *"              6 fields: second first
+//	"CRON_TZ=Asia/Shanghai 0 9 * * MON-FRI"   time zone prefix (TZ= also accepted)
+//	"@every 90s"                  fixed delay (any time.ParseDuration value)
+//	"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@yearly", "@annually"
+//
+// Fields accept *, ?, lists (1,3,5), ranges (1-5), steps (*/15, 0-30/10, 5/15)
+// and month / weekday names (JAN-DEC, SUN-SAT; 7 is also Sunday).
+// As in standard cron, when both day-of-month and day-of-week are restricted,
+// a time matches if either matches.
+//
+// Around DST changes, wall times skipped by a spring-forward never match, and wall
+// times repeated by a fall-back match in both occurrences. @every counts elapsed time.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule computes activation times.
+type Schedule interface {
+	// Next returns the first activation time after t, or the zero time if there is none.
+	Next(t time.Time) time.Time
+}
+
+// descriptors maps @-shortcuts to 6-field expressions.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// Parse parses a cron expression, evaluated in the local time zone unless it has a CRON_TZ= prefix.
+func Parse(expr string) (Schedule, error) {
+	return ParseInLocation(expr, time.Local)
+}
+
+// ParseInLocation parses a cron expression evaluated in loc (overridden by a CRON_TZ= prefix).
+func ParseInLocation(expr string, loc *time.Location) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, fmt.Errorf("empty schedule")
+	}
+
+	if strings.HasPrefix(expr, "CRON_TZ=") || strings.HasPrefix(expr, "TZ=") {
+		i := strings.IndexByte(expr, ' ')
+		if i < 0 {
+			return nil, fmt.Errorf("schedule %q: missing expression after time zone", expr)
+		}
+		name := expr[strings.IndexByte(expr, '=')+1 : i]
+		tz, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: unknown time zone %q: %w", expr, name, err)
+		}
+		loc = tz
+		expr = strings.TrimSpace(expr[i:])
+	}
+
+	if strings.HasPrefix(expr, "@every") {
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(expr, "@every")))
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", expr, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("schedule %q: interval must be positive", expr)
+		}
+		return Every(d), nil
+	}
+	if strings.HasPrefix(expr, "@") {
+		full, ok := descriptors[expr]
+		if !ok {
+			return nil, fmt.Errorf("schedule %q: unknown descriptor", expr)
+		}
+		expr = full
+	}
+
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("schedule %q: expected 5 or 6 fields, got %d", expr, len(fields))
+	}
+
+	s := &cronSchedule{loc: loc}
+	masks := []*uint64{&s.second, &s.minute, &s.hour, &s.dom, &s.month, &s.dow}
+	for i, field := range fields {
+		mask, err := parseField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %s: %w", expr, cronFields[i].name, err)
+		}
+		*masks[i] = mask
+	}
+	// 7 is an alias of Sunday
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domStar = fields[3] == "*" || fields[3] == "?"
+	s.dowStar = fields[5] == "*" || fields[5] == "?"
+	return s, nil
+}
+
+// MustParse is like Parse but panics on error. For package-level schedules.
+func MustParse(expr string) Schedule {
+	s, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Every returns a schedule activating every d after the previous time.
+func Every(d time.Duration) Schedule {
+	return everySchedule(d)
+}
+
+type everySchedule time.Duration
+
+func (e everySchedule) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
+
+// cronField describes the bounds and names of one field.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "second", min: 0, max: 59},
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}},
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}},
+}
+
+// parseField parses a comma-separated field into a bit mask.
+func parseField(field string, f cronField) (uint64, error) {
+	var mask uint64
+	for _, part := range strings.Split(field, ",") {
+		m, err := parseRange(part, f)
+		if err != nil {
+			return 0, err
+		}
+		mask |= m
+	}
+	return mask, nil
+}
+
+// parseRange parses "*", "a", "a-b" with an optional "/step".
+func parseRange(part string, f cronField) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(part, "/")
+	step := 1
+	if hasStep {
+		n, err := strconv.Atoi(stepPart)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid step %q", stepPart)
+		}
+		step = n
+	}
+
+	var lo, hi int
+	switch {
+	case rangePart == "*" || rangePart == "?":
+		lo, hi = f.min, f.max
+		if f.name == "day of week" {
+			hi = 6 // Don't double-count Sunday as 7
+		}
+	case strings.Contains(rangePart, "-"):
+		a, b, _ := strings.Cut(rangePart, "-")
+		var err error
+		if lo, err = parseValue(a, f); err != nil {
+			return 0, err
+		}
+		if hi, err = parseValue(b, f); err != nil {
+			return 0, err
+		}
+		if lo > hi {
+			return 0, fmt.Errorf("invalid range %q", rangePart)
+		}
+	default:
+		v, err := parseValue(rangePart, f)
+		if err != nil {
+			return 0, err
+		}
+		lo, hi = v, v
+		if hasStep {
+			hi = f.max // "5/15" means from 5 to the end every 15
+		}
+	}
+
+	var mask uint64
+	for v := lo; v <= hi; v += step {
+		mask |= 1 << uint(v)
+	}
+	return mask, nil
+}
+
+// parseValue parses a number or name within the field bounds.
+func parseValue(s string, f cronField) (int, error) {
+	if v, ok := f.names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// cronSchedule is a parsed cron expression; each field is a bit mask of allowed values.
+type cronSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+	domStar, dowStar                      bool
+	loc                                   *time.Location
+}
+
+// maxSearchYears bounds the search for impossible expressions like "0 0 30 2 *".
+const maxSearchYears = 5
+
+// Next returns the first matching time after t, in the schedule's time zone.
+// Minutes and seconds advance in elapsed time, so both occurrences of a wall time
+// repeated by a DST fall-back are visited; hours and days advance in wall time.
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	after := t
+	// Start at the next whole second
+	t = t.In(s.loc).Truncate(time.Second).Add(time.Second)
+	limit := t.Year() + maxSearchYears
+
+	for t.Year() <= limit {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = s.date(t.Year(), t.Month()+1, 1, 0)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = s.date(t.Year(), t.Month(), t.Day()+1, 0)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = s.date(t.Year(), t.Month(), t.Day(), t.Hour()+1)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Duration(60-t.Second()) * time.Second)
+			continue
+		}
+		if s.second&(1<<uint(t.Second())) == 0 {
+			t = t.Add(time.Second)
+			continue
+		}
+		if !t.After(after) {
+			// A wall time step resolved to the earlier occurrence of a repeated hour
+			t = t.Add(time.Second)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// date returns the start of a wall-clock hour in the schedule's time zone (values are
+// normalized as by time.Date). An hour skipped by a DST gap resolves to the end of the gap.
+func (s *cronSchedule) date(year int, month time.Month, day, hour int) time.Time {
+	t := time.Date(year, month, day, hour, 0, 0, 0, s.loc)
+	want := time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	got := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	if got.Before(want) {
+		// time.Date picked the offset before the gap
+		t = t.Add(want.Sub(got))
+	}
+	return t
+}
+
+// dayMatches applies the cron day-of-month / day-of-week rule.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,295 @@
+package schedule
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s not available: %v", name, err)
+	}
+	return loc
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@every",
+		"@every 0s",
+		"@every -1m",
+		"@fortnightly",
+		"CRON_TZ=Nowhere/City * * * * *",
+		"CRON_TZ=UTC",
+	}
+	for _, expr := range tests {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	utc := time.UTC
+	newYork := mustLoad(t, "America/New_York")
+	santiago := mustLoad(t, "America/Santiago") // Springs forward at midnight
+
+	tests := []struct {
+		name string
+		expr string
+		loc  *time.Location // Location of ParseInLocation, UTC if nil
+		from time.Time
+		want []time.Time // Successive activations
+	}{
+		{
+			name: "5 fields",
+			expr: "*/15 * * * *",
+			from: time.Date(2024, 1, 1, 10, 7, 30, 0, utc),
+			want: []time.Time{
+				time.Date(2024, 1, 1, 10, 15, 0, 0, utc),
+				time.Date(2024, 1, 1, 10, 30, 0, 0, utc),
+			},
+		},
+		{
+			name: "6 fields",
+			expr: "30 */5 * * * *",
+			from: time.Date(2024, 1, 1, 10, 5, 30, 0, utc),
+			want: []time.Time{
+				time.Date(2024, 1, 1, 10, 10, 30, 0, utc),
+				time.Date(2024, 1, 1, 10, 15, 30, 0, utc),
+			},
+		},
+		{
+			name: "exact match is excluded",
+			expr: "0 9 * * *",
+			from: time.Date(2024, 1, 1, 9, 0, 0, 0, utc),
+			want: []time.Time{time.Date(2024, 1, 2, 9, 0, 0, 0, utc)},
+		},
+		{
+			name: "sub-second start",
+			expr: "* * * * * *",
+			from: time.Date(2024, 1, 1, 9, 0, 0, 500, utc),
+			want: []time.Time{time.Date(2024, 1, 1, 9, 0, 1, 0, utc)},
+		},
+		{
+			name: "weekday names",
+			expr: "0 9 * * MON-FRI",
+			from: time.Date(2024, 1, 5, 10, 0, 0, 0, utc), // Friday
+			want: []time.Time{
+				time.Date(2024, 1, 8, 9, 0, 0, 0, utc),
+				time.Date(2024, 1, 9, 9, 0, 0, 0, utc),
+			},
+		},
+		{
+			name: "7 is Sunday",
+			expr: "0 0 * * 7",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, utc),
+			want: []time.Time{time.Date(2024, 1, 7, 0, 0, 0, 0, utc)},
+		},
+		{
+			name: "day of month or day of week",
+			expr: "0 0 1 * MON",
+			from: time.Date(2024, 1, 30, 0, 0, 0, 0, utc), // Tuesday
+			want: []time.Time{
+				time.Date(2024, 2, 1, 0, 0, 0, 0, utc),
+				time.Date(2024, 2, 5, 0, 0, 0, 0, utc),
+			},
+		},
+		{
+			name: "leap day",
+			expr: "0 0 29 2 *",
+			from: time.Date(2024, 3, 1, 0, 0, 0, 0, utc),
+			want: []time.Time{time.Date(2028, 2, 29, 0, 0, 0, 0, utc)},
+		},
+		{
+			name: "impossible date",
+			expr: "0 0 30 2 *",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, utc),
+			want: []time.Time{{}},
+		},
+		{
+			name: "descriptor",
+			expr: "@monthly",
+			from: time.Date(2024, 1, 15, 0, 0, 0, 0, utc),
+			want: []time.Time{
+				time.Date(2024, 2, 1, 0, 0, 0, 0, utc),
+				time.Date(2024, 3, 1, 0, 0, 0, 0, utc),
+			},
+		},
+		{
+			name: "@every",
+			expr: "@every 90s",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, utc),
+			want: []time.Time{
+				time.Date(2024, 1, 1, 0, 1, 30, 0, utc),
+				time.Date(2024, 1, 1, 0, 3, 0, 0, utc),
+			},
+		},
+		{
+			name: "@every counts elapsed time across DST",
+			expr: "@every 1h",
+			from: time.Date(2024, 11, 3, 0, 30, 0, 0, newYork),
+			want: []time.Time{
+				time.Date(2024, 11, 3, 5, 30, 0, 0, utc), // 01:30 EDT
+				time.Date(2024, 11, 3, 6, 30, 0, 0, utc), // 01:30 EST
+			},
+		},
+		{
+			name: "CRON_TZ prefix",
+			expr: "CRON_TZ=America/New_York 0 9 * * *",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, utc),
+			want: []time.Time{
+				time.Date(2024, 1, 1, 14, 0, 0, 0, utc),
+				time.Date(2024, 1, 2, 14, 0, 0, 0, utc),
+			},
+		},
+		{
+			name: "TZ prefix overrides the location",
+			expr: "TZ=UTC 0 9 * * *",
+			loc:  newYork,
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, utc),
+			want: []time.Time{time.Date(2024, 1, 1, 9, 0, 0, 0, utc)},
+		},
+		{
+			name: "spring forward skips the missing hour",
+			expr: "30 2 * * *",
+			loc:  newYork,
+			from: time.Date(2024, 3, 9, 12, 0, 0, 0, newYork),
+			want: []time.Time{
+				time.Date(2024, 3, 11, 2, 30, 0, 0, newYork),
+			},
+		},
+		{
+			name: "spring forward keeps elapsed minutes",
+			expr: "*/30 * * * *",
+			loc:  newYork,
+			from: time.Date(2024, 3, 10, 1, 15, 0, 0, newYork),
+			want: []time.Time{
+				time.Date(2024, 3, 10, 6, 30, 0, 0, utc), // 01:30 EST
+				time.Date(2024, 3, 10, 7, 0, 0, 0, utc),  // 03:00 EDT
+				time.Date(2024, 3, 10, 7, 30, 0, 0, utc), // 03:30 EDT
+			},
+		},
+		{
+			name: "spring forward at midnight skips the day",
+			expr: "@daily",
+			loc:  santiago,
+			from: time.Date(2024, 9, 7, 12, 0, 0, 0, santiago),
+			want: []time.Time{
+				time.Date(2024, 9, 9, 3, 0, 0, 0, utc), // 2024-09-08 has no 00:00
+				time.Date(2024, 9, 10, 3, 0, 0, 0, utc),
+			},
+		},
+		{
+			name: "hourly job at the end of a midnight gap",
+			expr: "@hourly",
+			loc:  santiago,
+			from: time.Date(2024, 9, 7, 23, 30, 0, 0, santiago),
+			want: []time.Time{
+				time.Date(2024, 9, 8, 4, 0, 0, 0, utc), // 01:00 -03
+				time.Date(2024, 9, 8, 5, 0, 0, 0, utc),
+			},
+		},
+		{
+			name: "fall back visits both occurrences",
+			expr: "*/30 * * * *",
+			loc:  newYork,
+			from: time.Date(2024, 11, 3, 4, 45, 0, 0, utc), // 00:45 EDT
+			want: []time.Time{
+				time.Date(2024, 11, 3, 5, 0, 0, 0, utc),  // 01:00 EDT
+				time.Date(2024, 11, 3, 5, 30, 0, 0, utc), // 01:30 EDT
+				time.Date(2024, 11, 3, 6, 0, 0, 0, utc),  // 01:00 EST
+				time.Date(2024, 11, 3, 6, 30, 0, 0, utc), // 01:30 EST
+				time.Date(2024, 11, 3, 7, 0, 0, 0, utc),  // 02:00 EST
+			},
+		},
+		{
+			name: "fall back from the second occurrence",
+			expr: "*/5 * * * *",
+			loc:  newYork,
+			from: time.Date(2024, 11, 3, 6, 2, 0, 0, utc), // 01:02 EST
+			want: []time.Time{
+				time.Date(2024, 11, 3, 6, 5, 0, 0, utc), // 01:05 EST
+				time.Date(2024, 11, 3, 6, 10, 0, 0, utc),
+			},
+		},
+		{
+			name: "fall back daily job in the repeated hour",
+			expr: "30 1 * * *",
+			loc:  newYork,
+			from: time.Date(2024, 11, 2, 12, 0, 0, 0, newYork),
+			want: []time.Time{
+				time.Date(2024, 11, 3, 5, 30, 0, 0, utc), // 01:30 EDT
+				time.Date(2024, 11, 3, 6, 30, 0, 0, utc), // 01:30 EST
+				time.Date(2024, 11, 4, 6, 30, 0, 0, utc),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := tt.loc
+			if loc == nil {
+				loc = utc
+			}
+			s, err := ParseInLocation(tt.expr, loc)
+			if err != nil {
+				t.Fatalf("ParseInLocation(%q) = %v", tt.expr, err)
+			}
+			from := tt.from
+			for i, want := range tt.want {
+				got := s.Next(from)
+				if !got.Equal(want) {
+					t.Fatalf("activation %d after %v = %v, want %v", i, from, got, want)
+				}
+				from = got
+			}
+		})
+	}
+}
+
+func TestSchedulerAcrossFallBack(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+	s, err := ParseInLocation("*/30 * * * *", newYork)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock := NewFakeClock(time.Date(2024, 11, 3, 4, 45, 0, 0, time.UTC)) // 00:45 EDT
+	runs := make(chan time.Time, 10)
+	scheduler := NewScheduler(WithClock(clock))
+	if err := scheduler.Add("job", s, func(ctx context.Context) error {
+		runs <- clock.Now()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	// 01:00 EDT, 01:30 EDT, 01:00 EST, 01:30 EST, 02:00 EST
+	for i := 0; i < 5; i++ {
+		clock.BlockUntil(1)
+		next := scheduler.Entries()[0].Next
+		if want := time.Date(2024, 11, 3, 5, 0, 0, 0, time.UTC).Add(time.Duration(i) * 30 * time.Minute); !next.Equal(want) {
+			t.Fatalf("run %d scheduled at %v, want %v", i, next, want)
+		}
+		clock.Set(next)
+		if got := <-runs; !got.Equal(next) {
+			t.Fatalf("run %d at %v, want %v", i, got, next)
+		}
+	}
+}
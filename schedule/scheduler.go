@@ -0,0 +1,208 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Job is the work run on a schedule.
+// ctx is cancelled when the job is removed or the scheduler stops.
+type Job func(ctx context.Context) error
+
+// Entry describes a scheduled job.
+type Entry struct {
+	Name string
+	Next time.Time // Next activation (zero if not scheduled)
+	Prev time.Time // Last activation (zero if never run)
+}
+
+// Scheduler runs jobs on schedules.
+// Each job runs in its own goroutine; a run that is still going when the next
+// activation is due delays it rather than overlapping with it.
+type Scheduler struct {
+	clock   Clock
+	onError func(name string, err error)
+
+	jobs    map[string]*entry
+	ctx     context.Context    // Parent of job contexts while started
+	cancel  context.CancelFunc // Cancels ctx
+	wg      sync.WaitGroup     // Running job goroutines
+	started bool
+	mu      sync.Mutex
+}
+
+// entry is one job with its run state.
+type entry struct {
+	name     string
+	schedule Schedule
+	job      Job
+	cancel   context.CancelFunc // Stops this job's goroutine (nil when not running)
+	next     time.Time
+	prev     time.Time
+}
+
+// Option is a scheduler configuration option function.
+type Option func(*Scheduler)
+
+// WithClock sets the clock, e.g. a FakeClock in tests.
+func WithClock(clock Clock) Option {
+	return func(s *Scheduler) {
+		s.clock = clock
+	}
+}
+
+// WithErrorHandler sets the function called with the error of a failed run.
+func WithErrorHandler(fn func(name string, err error)) Option {
+	return func(s *Scheduler) {
+		s.onError = fn
+	}
+}
+
+// NewScheduler creates a stopped scheduler.
+func NewScheduler(opts ...Option) *Scheduler {
+	s := &Scheduler{
+		clock: RealClock,
+		jobs:  make(map[string]*entry),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Add registers a job. If the scheduler is started, the job is scheduled immediately.
+func (s *Scheduler) Add(name string, schedule Schedule, job Job) error {
+	if schedule == nil || job == nil {
+		return fmt.Errorf("job %s: schedule and job are required", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.jobs[name]; exists {
+		return fmt.Errorf("job already exists: %s", name)
+	}
+	e := &entry{name: name, schedule: schedule, job: job}
+	s.jobs[name] = e
+	if s.started {
+		s.run(e)
+	}
+	return nil
+}
+
+// AddFunc parses a cron expression (see Parse) and registers a job.
+func (s *Scheduler) AddFunc(name, expr string, job Job) error {
+	schedule, err := Parse(expr)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", name, err)
+	}
+	return s.Add(name, schedule, job)
+}
+
+// Remove unregisters a job and cancels its context. Returns false if it doesn't exist.
+// It doesn't wait for a running activation to return.
+func (s *Scheduler) Remove(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.jobs[name]
+	if !ok {
+		return false
+	}
+	if e.cancel != nil {
+		e.cancel()
+	}
+	delete(s.jobs, name)
+	return true
+}
+
+// Start schedules all registered jobs. Starting a started scheduler is a no-op.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	for _, e := range s.jobs {
+		s.run(e)
+	}
+}
+
+// Stop cancels all jobs and waits for running activations to return.
+// Jobs stay registered; Start schedules them again.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	if !s.started {
+		s.mu.Unlock()
+		return
+	}
+	s.started = false
+	s.cancel()
+	for _, e := range s.jobs {
+		e.cancel = nil
+		e.next = time.Time{}
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// Entries returns the registered jobs sorted by name.
+func (s *Scheduler) Entries() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]Entry, 0, len(s.jobs))
+	for _, e := range s.jobs {
+		result = append(result, Entry{Name: e.name, Next: e.next, Prev: e.prev})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// run starts the goroutine of a job.
+// Note: caller must hold the lock (s.mu).
+func (s *Scheduler) run(e *entry) {
+	ctx, cancel := context.WithCancel(s.ctx)
+	e.cancel = cancel
+	s.wg.Add(1)
+	go s.loop(ctx, e)
+}
+
+// loop waits for each activation of a job and runs it.
+func (s *Scheduler) loop(ctx context.Context, e *entry) {
+	defer s.wg.Done()
+
+	for {
+		now := s.clock.Now()
+		next := e.schedule.Next(now)
+		if next.IsZero() {
+			return
+		}
+		s.mu.Lock()
+		e.next = next
+		s.mu.Unlock()
+
+		timer := s.clock.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+
+		s.mu.Lock()
+		e.prev = next
+		s.mu.Unlock()
+
+		if err := e.job(ctx); err != nil && s.onError != nil && ctx.Err() == nil {
+			s.onError(e.name, err)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package plugGo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+)
+
+// SignalAction is run by Boot when one of its action signals arrives while waiting
+// for shutdown (see WithSignalAction). Actions run one at a time.
+type SignalAction func(ctx context.Context, boot *Boot, sig os.Signal)
+
+// ReloadConfigAction re-reads boot.yaml and reloads the Entries (see Boot.ReloadConfig).
+// It is the default action for SIGHUP.
+func ReloadConfigAction(ctx context.Context, boot *Boot, sig os.Signal) {
+	boot.logger.Info(fmt.Sprintf("Received %v, reloading config", sig))
+	if err := boot.ReloadConfig(ctx); err != nil {
+		boot.logger.Error(fmt.Sprintf("Config reload incomplete: %v", err))
+	}
+}
+
+// DumpStateAction writes the Entries' state and all goroutine stacks to stderr
+// (see Boot.DumpState). It is the default action for SIGUSR1.
+func DumpStateAction(ctx context.Context, boot *Boot, sig os.Signal) {
+	boot.logger.Info(fmt.Sprintf("Received %v, dumping state to stderr", sig))
+	if err := boot.DumpState(os.Stderr); err != nil {
+		boot.logger.Error(fmt.Sprintf("Failed to dump state: %v", err))
+	}
+}
+
+// ToggleDebugAction switches the Boot and plugin loggers between debug level and
+// their previous levels (see Boot.ToggleDebug). It is the default action for SIGUSR2.
+func ToggleDebugAction(ctx context.Context, boot *Boot, sig os.Signal) {
+	if boot.ToggleDebug() {
+		boot.logger.Info(fmt.Sprintf("Received %v, debug logging on", sig))
+	} else {
+		boot.logger.Info(fmt.Sprintf("Received %v, debug logging off", sig))
+	}
+}
+
+// WithShutdownSignals sets the signals that start a graceful shutdown, defaults to
+// SIGINT and SIGTERM. A second one during the shutdown forces an immediate exit.
+func WithShutdownSignals(signals ...os.Signal) BootOption {
+	return func(c *BootConfig) {
+		c.ShutdownSignals = append([]os.Signal{}, signals...)
+	}
+}
+
+// WithSignalAction runs action when sig arrives while waiting for shutdown,
+// replacing the default action for that signal. A nil action disables it.
+func WithSignalAction(sig os.Signal, action SignalAction) BootOption {
+	return func(c *BootConfig) {
+		if c.SignalActions == nil {
+			c.SignalActions = make(map[os.Signal]SignalAction)
+		}
+		c.SignalActions[sig] = action
+	}
+}
+
+// signalListener receives the shutdown and action signals of a Boot through one
+// registration, held from the wait for shutdown until the shutdown completes, so
+// action signals never fall back to their default handlers (which terminate the
+// process) in between.
+type signalListener struct {
+	ch      chan os.Signal
+	actions []os.Signal
+}
+
+// listenSignals registers the shutdown and action signals, nil if there are none.
+func (b *Boot) listenSignals() *signalListener {
+	l := &signalListener{ch: make(chan os.Signal, 1)}
+	for sig := range b.signalActions {
+		l.actions = append(l.actions, sig)
+	}
+	signals := append(append([]os.Signal{}, b.shutdownSignals...), l.actions...)
+	if len(signals) == 0 {
+		return nil
+	}
+	signal.Notify(l.ch, signals...)
+	return l
+}
+
+// stop releases the registration. Action signals are ignored from then on rather
+// than restored to their default handlers.
+func (l *signalListener) stop() {
+	if l == nil {
+		return
+	}
+	if len(l.actions) > 0 {
+		signal.Ignore(l.actions...)
+	}
+	signal.Stop(l.ch)
+}
+
+// waitForSignal waits for a shutdown signal, running the actions of action signals
+// meanwhile. It returns the shutdown signal, or nil when ctx is done first.
+func (b *Boot) waitForSignal(ctx context.Context, l *signalListener) os.Signal {
+	if l == nil {
+		<-ctx.Done()
+		return nil
+	}
+
+	for {
+		select {
+		case sig := <-l.ch:
+			if action, ok := b.signalActions[sig]; ok {
+				action(ctx, b, sig)
+				continue
+			}
+			b.logger.Info(fmt.Sprintf("Received %v, shutting down", sig))
+			return sig
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// shutdownForcible shuts down like Shutdown, but exits the process immediately
+// if a shutdown signal arrives before the graceful shutdown completes. Action
+// signals arriving meanwhile are logged and ignored.
+func (b *Boot) shutdownForcible(ctx context.Context, l *signalListener) *ShutdownReport {
+	if l == nil {
+		return b.Shutdown(ctx)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-l.ch:
+				if _, ok := b.signalActions[sig]; ok {
+					b.logger.Warn(fmt.Sprintf("Received %v during shutdown, ignored", sig))
+					continue
+				}
+				b.logger.Error(fmt.Sprintf("Received %v during shutdown, forcing exit", sig))
+				os.Exit(ShutdownExitAbandoned)
+			case <-done:
+				return
+			}
+		}
+	}()
+	defer close(done)
+	return b.Shutdown(ctx)
+}
+
+// Run bootstraps all Entries, waits until a shutdown signal arrives or ctx is done,
+// then shuts down. While waiting, action signals run their actions (by default
+// SIGHUP reloads the config, SIGUSR1 dumps state and SIGUSR2 toggles debug logging);
+// during the shutdown they are ignored, and a second shutdown signal forces an
+// immediate exit. Once Run returns, action signals stay ignored.
+// If Bootstrap fails, Run shuts down right away.
+//
+// Returns:
+//   - *ShutdownReport: the outcome of the shutdown
+//   - error: the Bootstrap error, nil if every Entry bootstrapped
+func (b *Boot) Run(ctx context.Context) (*ShutdownReport, error) {
+	err := b.Bootstrap(ctx)
+	signals := b.listenSignals()
+	defer signals.stop()
+	if err != nil {
+		b.logger.Error("Bootstrap incomplete, shutting down")
+	} else {
+		b.waitForSignal(ctx, signals)
+	}
+
+	// ctx may be done already; the shutdown has its own timeout
+	return b.shutdownForcible(context.WithoutCancel(ctx), signals), err
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package plugGo
+
+import (
+	"os"
+	"syscall"
+)
+
+// defaultSignalActions returns the default action signals.
+func defaultSignalActions() map[os.Signal]SignalAction {
+	return map[os.Signal]SignalAction{
+		syscall.SIGHUP:  ReloadConfigAction,
+		syscall.SIGUSR1: DumpStateAction,
+		syscall.SIGUSR2: ToggleDebugAction,
+	}
+}
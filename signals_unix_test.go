@@ -0,0 +1,64 @@
+//go:build !windows
+
+package plugGo
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRunIgnoresActionSignalsDuringShutdown(t *testing.T) {
+	var actions atomic.Int32
+	boot := newTestBoot(t, nil, WithShutdownSignals(),
+		WithSignalAction(syscall.SIGHUP, func(ctx context.Context, boot *Boot, sig os.Signal) {
+			actions.Add(1)
+		}))
+
+	inHook := make(chan struct{})
+	release := make(chan struct{})
+	GlobalAppCtx.AddShutdownHookWithContext("signal-test", 0, func(ctx context.Context) error {
+		close(inHook)
+		<-release
+		return nil
+	})
+	defer GlobalAppCtx.RemoveShutdownHook("signal-test")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = boot.Run(ctx)
+	}()
+
+	// While waiting for shutdown the action runs. Until Run listens, a registration
+	// of the test keeps SIGHUP from killing the process.
+	guard := make(chan os.Signal, 16)
+	signal.Notify(guard, syscall.SIGHUP)
+	deadline := time.Now().Add(time.Second)
+	for actions.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("SIGHUP action not run while waiting")
+		}
+		_ = syscall.Kill(os.Getpid(), syscall.SIGHUP)
+		time.Sleep(20 * time.Millisecond)
+	}
+	ran := actions.Load()
+	signal.Stop(guard)
+
+	// During the shutdown it is ignored; without a handler it would kill the process
+	cancel()
+	<-inHook
+	_ = syscall.Kill(os.Getpid(), syscall.SIGHUP)
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	<-done
+
+	if got := actions.Load(); got != ran {
+		t.Errorf("action ran %d times during shutdown", got-ran)
+	}
+}
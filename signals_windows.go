@@ -0,0 +1,13 @@
+//go:build windows
+
+package plugGo
+
+import (
+	"os"
+)
+
+// defaultSignalActions returns the default action signals.
+// Windows has no SIGHUP, SIGUSR1 or SIGUSR2, so there are none.
+func defaultSignalActions() map[os.Signal]SignalAction {
+	return map[os.Signal]SignalAction{}
+}
@@ -0,0 +1,135 @@
+package plugGo
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultSubscriptionBuffer is the buffer size used when Subscribe is called with a non-positive size.
+const DefaultSubscriptionBuffer = 16
+
+// StatusSubscription is a single subscriber's stream of status events.
+// Every subscription has its own buffer, so slow subscribers never steal events from others.
+type StatusSubscription struct {
+	id      uint64
+	ch      chan StatusEvent
+	dropped atomic.Uint64
+	closed  bool // Guarded by the owning broadcaster's mutex
+}
+
+// ID returns the subscription identifier, unique within its source.
+func (s *StatusSubscription) ID() uint64 {
+	return s.id
+}
+
+// Events returns the channel delivering status events.
+// The channel is closed when the subscription is cancelled.
+func (s *StatusSubscription) Events() <-chan StatusEvent {
+	return s.ch
+}
+
+// Dropped returns the number of events discarded because the subscriber's buffer was full.
+// When the buffer is full the oldest buffered event is discarded, so the latest status is never lost.
+func (s *StatusSubscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// statusBroadcaster fans out status events to any number of subscribers.
+type statusBroadcaster struct {
+	subs   map[uint64]*StatusSubscription
+	nextID uint64
+	mu     sync.Mutex
+}
+
+func newStatusBroadcaster() *statusBroadcaster {
+	return &statusBroadcaster{
+		subs: make(map[uint64]*StatusSubscription),
+	}
+}
+
+// subscribe registers a new subscriber and replays the given event to it.
+func (b *statusBroadcaster) subscribe(bufferSize int, replay StatusEvent) *StatusSubscription {
+	if bufferSize <= 0 {
+		bufferSize = DefaultSubscriptionBuffer
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	sub := &StatusSubscription{
+		id: b.nextID,
+		ch: make(chan StatusEvent, bufferSize),
+	}
+	sub.ch <- replay
+	b.subs[sub.id] = sub
+	return sub
+}
+
+// unsubscribe removes a subscriber and closes its channel.
+// Returns false if the subscription was not registered.
+func (b *statusBroadcaster) unsubscribe(sub *StatusSubscription) bool {
+	if sub == nil {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subs[sub.id] != sub {
+		return false
+	}
+	delete(b.subs, sub.id)
+	sub.closed = true
+	close(sub.ch)
+	return true
+}
+
+// unsubscribeAll removes every subscriber and closes their channels.
+func (b *statusBroadcaster) unsubscribeAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for id, sub := range b.subs {
+		delete(b.subs, id)
+		sub.closed = true
+		close(sub.ch)
+	}
+}
+
+// publish delivers an event to every subscriber without blocking.
+func (b *statusBroadcaster) publish(event StatusEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, sub := range b.subs {
+		if !sub.closed {
+			sub.deliver(event)
+		}
+	}
+}
+
+// deliver sends an event, discarding the oldest buffered event while the buffer is full.
+// Note: caller must hold the broadcaster lock.
+func (s *StatusSubscription) deliver(event StatusEvent) {
+	for {
+		select {
+		case s.ch <- event:
+			return
+		default:
+		}
+
+		select {
+		case <-s.ch:
+			s.dropped.Add(1)
+		default:
+		}
+	}
+}
+
+// count returns the number of active subscribers.
+func (b *statusBroadcaster) count() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
@@ -0,0 +1,127 @@
+package plugGo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBroadcasterReplaysOnSubscribe(t *testing.T) {
+	b := newStatusBroadcaster()
+	b.publish(StatusEvent{Status: StatusStarting}) // No subscriber yet: not buffered anywhere
+
+	sub := b.subscribe(4, StatusEvent{Status: StatusRunning, Reason: "replayed"})
+	b.publish(StatusEvent{Status: StatusStopping})
+
+	if got := <-sub.Events(); got.Status != StatusRunning || got.Reason != "replayed" {
+		t.Errorf("first event = %+v, want the replay", got)
+	}
+	if got := <-sub.Events(); got.Status != StatusStopping {
+		t.Errorf("second event = %s, want Stopping", got.Status)
+	}
+	if b.count() != 1 {
+		t.Errorf("count = %d, want 1", b.count())
+	}
+}
+
+func TestBroadcasterDropsOldest(t *testing.T) {
+	b := newStatusBroadcaster()
+	slow := b.subscribe(2, StatusEvent{Status: StatusIdle})
+	fast := b.subscribe(8, StatusEvent{Status: StatusIdle})
+
+	events := []PluginStatus{StatusStarting, StatusRunning, StatusStopping, StatusStopped}
+	for _, status := range events {
+		b.publish(StatusEvent{Status: status})
+	}
+
+	// The slow subscriber keeps the newest events; the others are counted as dropped
+	if got := drain(slow); !equalStatuses(got, []PluginStatus{StatusStopping, StatusStopped}) {
+		t.Errorf("slow subscriber got %v", got)
+	}
+	if slow.Dropped() != 3 {
+		t.Errorf("slow subscriber dropped %d, want 3", slow.Dropped())
+	}
+	// Other subscribers are unaffected
+	if got := drain(fast); !equalStatuses(got, append([]PluginStatus{StatusIdle}, events...)) {
+		t.Errorf("fast subscriber got %v", got)
+	}
+	if fast.Dropped() != 0 {
+		t.Errorf("fast subscriber dropped %d", fast.Dropped())
+	}
+}
+
+func TestBroadcasterUnsubscribe(t *testing.T) {
+	b := newStatusBroadcaster()
+	sub := b.subscribe(0, StatusEvent{})
+	other := newStatusBroadcaster().subscribe(0, StatusEvent{})
+
+	if b.unsubscribe(other) {
+		t.Error("unsubscribed a foreign subscription")
+	}
+	if !b.unsubscribe(sub) {
+		t.Fatal("unsubscribe = false")
+	}
+	if b.unsubscribe(sub) {
+		t.Error("unsubscribed twice")
+	}
+	b.publish(StatusEvent{Status: StatusRunning}) // Must not panic on the closed channel
+	if got := drain(sub); len(got) != 1 {
+		t.Errorf("events after unsubscribe = %v, want only the replay", got)
+	}
+
+	a, c := b.subscribe(0, StatusEvent{}), b.subscribe(0, StatusEvent{})
+	b.unsubscribeAll()
+	drain(a)
+	drain(c)
+	if b.count() != 0 {
+		t.Errorf("count = %d after unsubscribeAll", b.count())
+	}
+}
+
+func TestInstanceSubscribersSeeEveryTransition(t *testing.T) {
+	instance, _ := newStubInstance("subscribed")
+	subs := []*StatusSubscription{instance.Subscribe(0), instance.Subscribe(0)}
+	ctx := context.Background()
+	if err := instance.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := instance.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+	instance.UnsubscribeAll()
+
+	want := []PluginStatus{StatusIdle, StatusStarting, StatusRunning, StatusStopping, StatusStopped}
+	for i, sub := range subs {
+		if got := drain(sub); !equalStatuses(got, want) {
+			t.Errorf("subscriber %d got %v, want %v", i, got, want)
+		}
+	}
+}
+
+// drain reads the buffered events of a subscription; the channel must be closed
+// or every remaining event already buffered.
+func drain(sub *StatusSubscription) []PluginStatus {
+	var statuses []PluginStatus
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return statuses
+			}
+			statuses = append(statuses, event.Status)
+		default:
+			return statuses
+		}
+	}
+}
+
+func equalStatuses(a, b []PluginStatus) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -2,8 +2,8 @@ package config
 
 // Config is the plugin configuration structure.
 type Config struct {
+	// Whether the instance runs is plugGo.InstanceMeta's "enabled", owned by its Entry
 	Name     string `yaml:"name"`     // Instance name
-	Enabled  bool   `yaml:"enabled"`  // Whether enabled
 	LogLevel string `yaml:"logLevel"` // Log level: debug, info, warn, error
 
 	// Add your custom config fields here
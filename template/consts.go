@@ -14,14 +14,6 @@ const (
 	// Plugin version
 	PluginVersion = "1.0.0"
 
-	// EntryTypeName Entry 类型名称
-	// Entry type name
-	EntryTypeName = "TemplateEntry"
-
-	// PluginDescription 插件描述模版，%s 会被实例名替换
-	// Plugin description template, %s will be replaced with instance name
-	PluginDescription = "Template plugin [%s]"
-
 	// LoggerPrefix 日志前缀模版，%s 会被实例名替换
 	// Logger prefix template, %s will be replaced with instance name
 	LoggerPrefix = "template-%s"
@@ -6,7 +6,6 @@ import (
 
 	"github.com/seencxy/plugGo"
 	plugGoConfig "github.com/seencxy/plugGo/config"
-	"github.com/seencxy/plugGo/registry"
 	"github.com/seencxy/plugGo/template/config"
 )
 
@@ -37,7 +36,6 @@ func (f *Factory) DefaultConfig() interface{} {
 	if err := plugGoConfig.UnmarshalYAML(defaultConfigData, cfg); err != nil {
 		return &config.Config{
 			Name:     "default",
-			Enabled:  true,
 			LogLevel: "info",
 			Interval: 60,
 			Endpoint: "https://api.example.com",
@@ -97,8 +95,3 @@ func (f *Factory) Create(id string, cfg interface{}, logger plugGo.Logger) (plug
 
 	return NewPlugin(id, c, logger), nil
 }
-
-// Register factory to registry on import
-func init() {
-	registry.RegisterFactory(NewFactory())
-}
@@ -18,9 +18,8 @@ type Plugin struct {
 	logger     plugGo.Logger
 	running    bool
 	stopCh     chan struct{}
-	status     plugGo.PluginStatus
-	statusCh   chan plugGo.StatusEvent
-	notifyCh   chan any // External notification channel
+	statusCh   chan plugGo.StatusEvent // Reports failures while running
+	notifyCh   chan any                // External notification channel
 	mu         sync.RWMutex
 }
 
@@ -32,7 +31,6 @@ func NewPlugin(id string, cfg *config.Config, logger plugGo.Logger) *Plugin {
 		cfg:        cfg,
 		logger:     logger,
 		stopCh:     make(chan struct{}),
-		status:     plugGo.StatusIdle,
 		statusCh:   make(chan plugGo.StatusEvent, 10), // buffered channel to avoid blocking
 		notifyCh:   make(chan any, 100),               // buffered channel for external notifications
 	}
@@ -53,14 +51,19 @@ func (p *Plugin) Version() string {
 	return PluginVersion
 }
 
-// Status returns the current plugin status.
+// Status reports whether the plugin is running. The lifecycle status is
+// tracked by the plugGo.PluginInstance wrapping the plugin.
 func (p *Plugin) Status() plugGo.PluginStatus {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
-	return p.status
+	if p.running {
+		return plugGo.StatusRunning
+	}
+	return plugGo.StatusStopped
 }
 
-// StatusNotify returns a read-only channel for receiving status change events.
+// StatusNotify returns the channel reporting failures while running
+// (see reportError), which move the instance to Error.
 func (p *Plugin) StatusNotify() <-chan plugGo.StatusEvent {
 	return p.statusCh
 }
@@ -71,18 +74,14 @@ func (p *Plugin) GetNotifyChannel() chan any {
 	return p.notifyCh
 }
 
-// updateStatus updates the plugin status and sends notification.
-// Note: caller must hold the lock (p.mu).
-func (p *Plugin) updateStatus(newStatus plugGo.PluginStatus, err error) {
-	if p.status != newStatus {
-		p.status = newStatus
-		// Send status event non-blocking
-		select {
-		case p.statusCh <- plugGo.StatusEvent{Status: newStatus, Error: err}:
-		default:
-			// Channel full, skip this event
-			p.logger.Warn("Status channel full, event dropped")
-		}
+// reportError reports a failure of a background worker, e.g. a lost connection.
+// The PluginInstance moves to Error; Start and Stop errors are simply returned.
+func (p *Plugin) reportError(err error) {
+	select {
+	case p.statusCh <- plugGo.StatusEvent{Status: plugGo.StatusError, Error: err}:
+	default:
+		// Channel full, an error is already pending
+		p.logger.Warn("Status channel full, error dropped:", err)
 	}
 }
 
@@ -95,24 +94,20 @@ func (p *Plugin) Start(ctx context.Context) error {
 		return fmt.Errorf("plugin already running")
 	}
 
-	if !p.cfg.Enabled {
-		p.logger.Info("Plugin is disabled")
-		return nil
-	}
-
 	p.logger.Info(fmt.Sprintf("Starting plugin, endpoint: %s, interval: %ds", p.cfg.Endpoint, p.cfg.Interval))
+	p.stopCh = make(chan struct{}) // Recreated so the plugin can be restarted after Stop
 
 	// TODO: Add your startup logic here
 	// Example: start background workers, initialize connections, etc.
 	//
 	// Example error handling:
 	// if err := p.initConnection(); err != nil {
-	//     p.updateStatus(plugGo.StatusError, err)
 	//     return fmt.Errorf("failed to initialize: %w", err)
 	// }
+	//
+	// A background worker failing later calls p.reportError(err).
 
 	p.running = true
-	p.updateStatus(plugGo.StatusRunning, nil)
 	return nil
 }
 
@@ -161,12 +156,10 @@ func (p *Plugin) Stop(ctx context.Context) error {
 	// Example error handling:
 	// if err := p.cleanup(); err != nil {
 	//     p.logger.Error("Cleanup failed:", err)
-	//     p.updateStatus(plugGo.StatusError, err)
 	//     return fmt.Errorf("failed to cleanup: %w", err)
 	// }
 
 	p.running = false
-	p.updateStatus(plugGo.StatusStopped, nil)
 	p.logger.Info("Plugin stopped")
 	return nil
 }
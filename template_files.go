@@ -0,0 +1,9 @@
+package plugGo
+
+import "embed"
+
+// TemplateFiles holds the sources of the plugin template (template/).
+// `pluggo new plugin` renders them to scaffold a new plugin.
+//
+//go:embed template/*.go template/config.yaml template/config/*.go
+var TemplateFiles embed.FS
@@ -0,0 +1,187 @@
+package plugGo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ConfigChange is a new config for one instance within a config transaction.
+type ConfigChange struct {
+	Instance *PluginInstance
+	Config   interface{}
+}
+
+// ConfigOutcome is the result of a config transaction for one instance.
+type ConfigOutcome string
+
+const (
+	// OutcomeApplied means the new config was applied and kept.
+	OutcomeApplied ConfigOutcome = "applied"
+	// OutcomeInvalid means the new config failed validation, nothing was applied.
+	OutcomeInvalid ConfigOutcome = "invalid"
+	// OutcomeFailed means reloading this instance failed and aborted the transaction.
+	OutcomeFailed ConfigOutcome = "failed"
+	// OutcomeRolledBack means the new config was applied, then reverted after another failure.
+	OutcomeRolledBack ConfigOutcome = "rolled_back"
+	// OutcomeRollbackFailed means reverting to the previous config failed.
+	OutcomeRollbackFailed ConfigOutcome = "rollback_failed"
+	// OutcomeSkipped means the instance was not reached because the transaction aborted.
+	OutcomeSkipped ConfigOutcome = "skipped"
+)
+
+// ConfigChangeResult is the per-instance result of a config transaction.
+type ConfigChangeResult struct {
+	InstanceID    string
+	Outcome       ConfigOutcome
+	Error         error // Validation or reload error
+	RollbackError error // Error while restoring the previous config
+}
+
+// ConfigTxResult is the result of ApplyConfigs.
+type ConfigTxResult struct {
+	// Committed is true if every instance runs its new config.
+	Committed bool
+	// Results holds one entry per change, in the order given.
+	Results []ConfigChangeResult
+}
+
+// Err returns an error summarizing the failed instances, or nil if committed.
+func (r *ConfigTxResult) Err() error {
+	if r.Committed {
+		return nil
+	}
+	var errs []error
+	for _, res := range r.Results {
+		if res.Error != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", res.InstanceID, res.Error))
+		}
+		if res.RollbackError != nil {
+			errs = append(errs, fmt.Errorf("%s: rollback: %w", res.InstanceID, res.RollbackError))
+		}
+	}
+	if len(errs) == 0 {
+		return errors.New("config transaction not committed")
+	}
+	return errors.Join(errs...)
+}
+
+// ApplyConfigs applies new configs to a set of instances atomically.
+// All configs are validated first; then instances are reloaded in the given order.
+// If any reload fails, the previous config is re-applied to every instance already
+// changed (and to the failed one, restarting it if it was running before).
+// No other lifecycle operation can run on the instances during the transaction.
+//
+// Returns an error only for malformed input (nil or duplicate instances);
+// per-instance failures are reported in the result.
+func ApplyConfigs(ctx context.Context, changes []ConfigChange) (*ConfigTxResult, error) {
+	seen := make(map[*PluginInstance]bool, len(changes))
+	for i, change := range changes {
+		if change.Instance == nil {
+			return nil, fmt.Errorf("change[%d]: instance is nil", i)
+		}
+		if seen[change.Instance] {
+			return nil, fmt.Errorf("change[%d]: duplicate instance %s", i, change.Instance.ID())
+		}
+		seen[change.Instance] = true
+	}
+
+	// Lock all instances in ID order so concurrent transactions cannot deadlock
+	locked := make([]*PluginInstance, 0, len(changes))
+	for _, change := range changes {
+		locked = append(locked, change.Instance)
+	}
+	sort.Slice(locked, func(i, j int) bool { return locked[i].ID() < locked[j].ID() })
+	for _, pi := range locked {
+		pi.opMu.Lock()
+		defer pi.opMu.Unlock()
+	}
+
+	result := &ConfigTxResult{Results: make([]ConfigChangeResult, len(changes))}
+	for i, change := range changes {
+		result.Results[i] = ConfigChangeResult{InstanceID: change.Instance.ID(), Outcome: OutcomeSkipped}
+	}
+
+	// 1. Validate everything before touching any instance
+	valid := true
+	for i, change := range changes {
+		if err := change.Instance.factory.ValidateConfig(change.Config); err != nil {
+			result.Results[i].Outcome = OutcomeInvalid
+			result.Results[i].Error = fmt.Errorf("config validation failed: %w", err)
+			valid = false
+		}
+	}
+	if !valid {
+		return result, nil
+	}
+
+	// 2. Reload in order, remembering previous configs for rollback
+	type applied struct {
+		index      int
+		oldConfig  interface{}
+		wasRunning bool
+	}
+	var done []applied
+
+	for i, change := range changes {
+		pi := change.Instance
+		prev := applied{
+			index:      i,
+			oldConfig:  pi.GetConfig(),
+			wasRunning: pi.Status() == StatusRunning,
+		}
+
+		if err := pi.updateConfig(ctx, change.Config); err != nil {
+			result.Results[i].Outcome = OutcomeFailed
+			result.Results[i].Error = err
+			// Restore the failed instance too, it may be left half-reloaded
+			if pi.Status() == StatusError && prev.wasRunning {
+				result.Results[i].RollbackError = pi.restoreConfig(ctx, prev.oldConfig, true)
+			}
+
+			// 3. Roll back already changed instances in reverse order
+			for j := len(done) - 1; j >= 0; j-- {
+				a := done[j]
+				res := &result.Results[a.index]
+				if err := changes[a.index].Instance.restoreConfig(ctx, a.oldConfig, a.wasRunning); err != nil {
+					res.Outcome = OutcomeRollbackFailed
+					res.RollbackError = err
+				} else {
+					res.Outcome = OutcomeRolledBack
+				}
+			}
+			return result, nil
+		}
+
+		result.Results[i].Outcome = OutcomeApplied
+		done = append(done, prev)
+	}
+
+	result.Committed = true
+	return result, nil
+}
+
+// restoreConfig re-applies a previous config, restarting the plugin if it was
+// running before and has since failed.
+// Note: caller must hold pi.opMu.
+func (pi *PluginInstance) restoreConfig(ctx context.Context, oldConfig interface{}, wasRunning bool) error {
+	pi.mu.Lock()
+	currentConfig := pi.config
+	pi.config = oldConfig
+	pi.mu.Unlock()
+	changes := configChangeSummary(currentConfig, oldConfig)
+
+	from := pi.Status()
+	var err error
+	if from == StatusError && wasRunning {
+		if err = pi.plugin.Reload(oldConfig); err == nil {
+			err = pi.restart(ctx)
+		}
+	} else {
+		err = pi.reload(ctx, currentConfig, oldConfig)
+	}
+
+	pi.recordOperation(ctx, HistoryRollback, from, err, changes)
+	return err
+}
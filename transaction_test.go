@@ -0,0 +1,140 @@
+package plugGo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// callLog collects the calls of several stub plugins in order.
+type callLog struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (l *callLog) add(call string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, call)
+}
+
+func (l *callLog) get() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.calls...)
+}
+
+// startStubInstances starts stub instances sharing a call log, which is reset once they run.
+func startStubInstances(t *testing.T, log *callLog, ids ...string) ([]*PluginInstance, []*stubPlugin) {
+	t.Helper()
+	var instances []*PluginInstance
+	var plugins []*stubPlugin
+	for _, id := range ids {
+		instance, plugin := newStubInstance(id)
+		if err := instance.Start(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		plugin.onCall = log.add
+		instances = append(instances, instance)
+		plugins = append(plugins, plugin)
+	}
+	return instances, plugins
+}
+
+func TestApplyConfigsCommits(t *testing.T) {
+	log := &callLog{}
+	instances, _ := startStubInstances(t, log, "a", "b")
+	result, err := ApplyConfigs(context.Background(), []ConfigChange{
+		{Instance: instances[0], Config: &stubConfig{Value: "v2"}},
+		{Instance: instances[1], Config: &stubConfig{Value: "v2"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Committed || result.Err() != nil {
+		t.Fatalf("result = %+v", result)
+	}
+	for _, res := range result.Results {
+		if res.Outcome != OutcomeApplied {
+			t.Errorf("%s: outcome %s", res.InstanceID, res.Outcome)
+		}
+	}
+	if got, want := log.get(), []string{"a reload:v2", "b reload:v2"}; !equalStrings(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestApplyConfigsRollsBackInReverseOrder(t *testing.T) {
+	log := &callLog{}
+	instances, plugins := startStubInstances(t, log, "a", "b", "c", "d")
+	failed := false
+	plugins[2].reloadErr = func() error {
+		if failed {
+			return nil
+		}
+		failed = true
+		return errors.New("bad value")
+	}
+
+	var changes []ConfigChange
+	for _, instance := range instances {
+		changes = append(changes, ConfigChange{Instance: instance, Config: &stubConfig{Value: "v2"}})
+	}
+	result, err := ApplyConfigs(context.Background(), changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Committed || result.Err() == nil {
+		t.Fatalf("result = %+v, want not committed", result)
+	}
+
+	// The failed instance is restored and restarted first, then the changed ones in reverse order
+	want := []string{
+		"a reload:v2", "b reload:v2", "c reload:v2",
+		"c reload:v1", "c stop", "c start",
+		"b reload:v1",
+		"a reload:v1",
+	}
+	if got := log.get(); !equalStrings(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+
+	wantOutcomes := []ConfigOutcome{OutcomeRolledBack, OutcomeRolledBack, OutcomeFailed, OutcomeSkipped}
+	for i, res := range result.Results {
+		if res.Outcome != wantOutcomes[i] || res.RollbackError != nil {
+			t.Errorf("%s: outcome %s (rollback error %v), want %s", res.InstanceID, res.Outcome, res.RollbackError, wantOutcomes[i])
+		}
+		if cfg := instances[i].GetConfig().(*stubConfig); cfg.Value != "v1" {
+			t.Errorf("%s: config %q, want v1", res.InstanceID, cfg.Value)
+		}
+		if instances[i].Status() != StatusRunning {
+			t.Errorf("%s: status %s, want Running", res.InstanceID, instances[i].Status())
+		}
+	}
+}
+
+func TestApplyConfigsValidatesFirst(t *testing.T) {
+	log := &callLog{}
+	instances, _ := startStubInstances(t, log, "a", "b")
+	result, err := ApplyConfigs(context.Background(), []ConfigChange{
+		{Instance: instances[0], Config: &stubConfig{Value: "v2"}},
+		{Instance: instances[1], Config: &stubConfig{Invalid: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Committed || result.Results[0].Outcome != OutcomeSkipped || result.Results[1].Outcome != OutcomeInvalid {
+		t.Errorf("result = %+v", result)
+	}
+	if got := log.get(); len(got) != 0 {
+		t.Errorf("calls = %v, want none", got)
+	}
+
+	if _, err := ApplyConfigs(context.Background(), []ConfigChange{
+		{Instance: instances[0], Config: &stubConfig{}},
+		{Instance: instances[0], Config: &stubConfig{}},
+	}); err == nil {
+		t.Error("ApplyConfigs accepted a duplicate instance")
+	}
+}